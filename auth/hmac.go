@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+)
+
+// HMACSigner signs outgoing requests with Polymarket's L2 (API-key) scheme.
+// It is meant to be wired into transport.Policy.Mutate so every request made
+// through a signed client carries POLY_ADDRESS/POLY_SIGNATURE/POLY_TIMESTAMP/
+// POLY_API_KEY/POLY_PASSPHRASE headers.
+type HMACSigner struct {
+	Address string
+	Creds   APICreds
+
+	funder string
+	mode   AuthMode
+	clock  func() time.Time
+}
+
+// HMACSignerOption configures an HMACSigner.
+type HMACSignerOption func(*HMACSigner)
+
+// WithClock overrides the signer's notion of "now", so tests can pin the
+// timestamp that goes into the signed pre-hash string.
+func WithClock(now func() time.Time) HMACSignerOption {
+	return func(s *HMACSigner) {
+		if now != nil {
+			s.clock = now
+		}
+	}
+}
+
+// WithProxyWallet configures the signer for a proxy-wallet setup: requests
+// are still signed by Address/Creds, but POLY_ADDRESS identifies funder (the
+// address actually holding the funds) and POLY_SIGNATURE_TYPE is set to
+// POLY_PROXY.
+func WithProxyWallet(funder string) HMACSignerOption {
+	return func(s *HMACSigner) {
+		s.funder = funder
+		s.mode = AuthModeProxy
+	}
+}
+
+// WithGnosisSafe is WithProxyWallet's counterpart for Gnosis Safe wallets.
+func WithGnosisSafe(funder string) HMACSignerOption {
+	return func(s *HMACSigner) {
+		s.funder = funder
+		s.mode = AuthModeGnosisSafe
+	}
+}
+
+// NewHMACSigner builds a signer for the given on-chain address and API creds.
+// creds.Funder is copied as the initial funder address, but the signer signs
+// as a plain EOA (no funder sent) unless WithProxyWallet or WithGnosisSafe is
+// given, and both of those replace the funder with their own argument.
+func NewHMACSigner(address string, creds APICreds, opts ...HMACSignerOption) *HMACSigner {
+	s := &HMACSigner{Address: address, Creds: creds, funder: creds.Funder, clock: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Mutate implements the transport.Transport request-mutation hook.
+func (s *HMACSigner) Mutate(req *http.Request, body []byte) error {
+	return ApplyL2Headers(req, s.Creds, s.Address, body, L2SignOptions{
+		Now:    s.clock,
+		Funder: s.funder,
+		Mode:   s.mode,
+	})
+}
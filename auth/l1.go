@@ -1,9 +1,116 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
-// TODO: implement exact Polymarket typed-data and API call to derive creds.
-// This should be kept aligned with official clients: "createOrDeriveApiKey".
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/star-gazer111/poly-go-clob-client/internal/transport"
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+// PolygonChainID is the EIP-712 domain chain id Polymarket's production CLOB
+// expects when signing the L1 "ClobAuth" message (Polygon mainnet).
+const PolygonChainID = 137
+
+// clobAuthAttestation is the fixed text every ClobAuth typed-data payload
+// carries, matching Polymarket's official clients byte-for-byte. It isn't
+// user data - just part of what gets signed to prove wallet control.
+const clobAuthAttestation = "This message attests that I control the given wallet"
+
+// clobAuthTypes is the EIP-712 type definition for the L1 signature used to
+// create or derive an API key.
+var clobAuthTypes = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+	},
+	"ClobAuth": {
+		{Name: "address", Type: "address"},
+		{Name: "timestamp", Type: "string"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "message", Type: "string"},
+	},
+}
+
+// L1AuthHeaders builds the POLY_ADDRESS/POLY_SIGNATURE/POLY_TIMESTAMP/
+// POLY_NONCE header set every L1-authed endpoint expects (today that's just
+// api-key create/derive), so a future L1 endpoint can reuse the same signing
+// envelope instead of re-deriving the header names.
+func L1AuthHeaders(address, signature, timestamp, nonce string) map[string]string {
+	return map[string]string{
+		"POLY_ADDRESS":   address,
+		"POLY_SIGNATURE": signature,
+		"POLY_TIMESTAMP": timestamp,
+		"POLY_NONCE":     nonce,
+	}
+}
+
+// DeriveAPICreds implements Polymarket's "create or derive API key" flow: it
+// has signer sign an EIP-712 ClobAuth message proving control of its
+// address, then POSTs to /auth/api-key to create a fresh key. If that fails
+// (most commonly because a key already exists for this address) it falls
+// back to GET /auth/derive-api-key, which returns the existing key instead
+// of erroring.
 func DeriveAPICreds(ctx context.Context, signer Signer, baseURL string) (APICreds, error) {
-	return APICreds{}, nil
+	if signer == nil {
+		return APICreds{}, types.ValidationErr("auth: signer must not be nil")
+	}
+
+	address := signer.Address().Hex()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce := big.NewInt(0)
+
+	typedData := apitypes.TypedData{
+		Types:       clobAuthTypes,
+		PrimaryType: "ClobAuth",
+		Domain: apitypes.TypedDataDomain{
+			Name:    "ClobAuthDomain",
+			Version: "1",
+			ChainId: (*math.HexOrDecimal256)(big.NewInt(PolygonChainID)),
+		},
+		Message: apitypes.TypedDataMessage{
+			"address":   address,
+			"timestamp": timestamp,
+			"nonce":     nonce,
+			"message":   clobAuthAttestation,
+		},
+	}
+
+	sig, err := signer.SignTypedData(ctx, typedData)
+	if err != nil {
+		return APICreds{}, types.WithSource(types.KindValidation, fmt.Errorf("auth: sign ClobAuth typed data: %w", err))
+	}
+
+	headers := L1AuthHeaders(address, hexutil.Encode(sig), timestamp, nonce.String())
+	base := strings.TrimRight(baseURL, "/")
+	tr := transport.NewTransport(http.DefaultClient, transport.DefaultPolicy())
+
+	if b, err := tr.DoJSON(ctx, http.MethodPost, base+"/auth/api-key", headers, nil); err == nil {
+		return decodeAPICreds(b)
+	}
+
+	b, err := tr.DoJSON(ctx, http.MethodGet, base+"/auth/derive-api-key", headers, nil)
+	if err != nil {
+		return APICreds{}, err
+	}
+	return decodeAPICreds(b)
+}
+
+func decodeAPICreds(b []byte) (APICreds, error) {
+	var creds APICreds
+	if err := json.Unmarshal(b, &creds); err != nil {
+		return APICreds{}, types.WithSource(types.KindInternal, fmt.Errorf("auth: decode api creds: %w", err))
+	}
+	return creds, nil
 }
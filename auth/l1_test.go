@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+type fakeL1Signer struct {
+	addr     common.Address
+	sig      []byte
+	err      error
+	captured apitypes.TypedData
+}
+
+func (f *fakeL1Signer) Address() common.Address { return f.addr }
+
+func (f *fakeL1Signer) SignTypedData(ctx context.Context, typedData any) ([]byte, error) {
+	td, ok := typedData.(apitypes.TypedData)
+	if !ok {
+		return nil, errors.New("SignTypedData: unexpected payload type")
+	}
+	f.captured = td
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.sig, nil
+}
+
+func TestDeriveAPICreds_CreatesNewKeyViaPOST(t *testing.T) {
+	var posts, gets int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/auth/api-key":
+			posts++
+			if got := r.Header.Get("POLY_NONCE"); got != "0" {
+				t.Errorf("POLY_NONCE = %q, want %q", got, "0")
+			}
+			if got := r.Header.Get("POLY_SIGNATURE"); got != "0x1234" {
+				t.Errorf("POLY_SIGNATURE = %q, want %q", got, "0x1234")
+			}
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(APICreds{Key: "new-key", Secret: "new-secret", Passphrase: "new-pass"})
+		case r.Method == http.MethodGet && r.URL.Path == "/auth/derive-api-key":
+			gets++
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(APICreds{Key: "derived-key"})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	signer := &fakeL1Signer{addr: common.HexToAddress("0xAbC0000000000000000000000000000000000d"), sig: []byte{0x12, 0x34}}
+
+	creds, err := DeriveAPICreds(context.Background(), signer, srv.URL)
+	if err != nil {
+		t.Fatalf("DeriveAPICreds: %v", err)
+	}
+	if creds.Key != "new-key" {
+		t.Fatalf("expected the freshly created key, got %q", creds.Key)
+	}
+	if posts != 1 || gets != 0 {
+		t.Fatalf("expected exactly 1 POST and 0 GETs, got posts=%d gets=%d", posts, gets)
+	}
+
+	if signer.captured.PrimaryType != "ClobAuth" {
+		t.Errorf("PrimaryType = %q, want ClobAuth", signer.captured.PrimaryType)
+	}
+	if signer.captured.Domain.Name != "ClobAuthDomain" {
+		t.Errorf("Domain.Name = %q, want ClobAuthDomain", signer.captured.Domain.Name)
+	}
+	if signer.captured.Message["address"] != signer.addr.Hex() {
+		t.Errorf("message address = %v, want %v", signer.captured.Message["address"], signer.addr.Hex())
+	}
+}
+
+func TestDeriveAPICreds_FallsBackToDeriveWhenCreateFails(t *testing.T) {
+	var posts, gets int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/auth/api-key":
+			posts++
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"api key already exists"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/auth/derive-api-key":
+			gets++
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(APICreds{Key: "existing-key", Secret: "existing-secret", Passphrase: "existing-pass"})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	signer := &fakeL1Signer{addr: common.HexToAddress("0xAbC0000000000000000000000000000000000d"), sig: []byte{0x56, 0x78}}
+
+	creds, err := DeriveAPICreds(context.Background(), signer, srv.URL)
+	if err != nil {
+		t.Fatalf("DeriveAPICreds: %v", err)
+	}
+	if creds.Key != "existing-key" {
+		t.Fatalf("expected the derived existing key, got %q", creds.Key)
+	}
+	if posts != 1 || gets != 1 {
+		t.Fatalf("expected 1 POST then 1 GET fallback, got posts=%d gets=%d", posts, gets)
+	}
+}
+
+func TestDeriveAPICreds_ReturnsStatusErrorWhenBothEndpointsFail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	signer := &fakeL1Signer{addr: common.HexToAddress("0xAbC0000000000000000000000000000000000d"), sig: []byte{0x01}}
+
+	_, err := DeriveAPICreds(context.Background(), signer, srv.URL)
+	if err == nil {
+		t.Fatal("expected an error when both create and derive fail")
+	}
+}
+
+func TestDeriveAPICreds_NilSignerIsValidationError(t *testing.T) {
+	_, err := DeriveAPICreds(context.Background(), nil, "http://example.com")
+	if err == nil {
+		t.Fatal("expected a validation error for a nil signer")
+	}
+}
+
+func TestL1AuthHeaders_SetsExpectedKeys(t *testing.T) {
+	h := L1AuthHeaders("0xAddr", "0xSig", "123", "0")
+	want := map[string]string{
+		"POLY_ADDRESS":   "0xAddr",
+		"POLY_SIGNATURE": "0xSig",
+		"POLY_TIMESTAMP": "123",
+		"POLY_NONCE":     "0",
+	}
+	for k, v := range want {
+		if h[k] != v {
+			t.Errorf("%s = %q, want %q", k, h[k], v)
+		}
+	}
+}
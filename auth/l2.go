@@ -1,10 +1,97 @@
 package auth
 
-import "net/http"
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
 
-// ApplyL2Headers applies Polymarket API key headers to a request.
-// Exact header names/format should match official clients.
-func ApplyL2Headers(req *http.Request, creds APICreds) {
-	// TODO: req.Header.Set("POLY_API_KEY", creds.Key) etc.
-	// Ensure that we NEVER log these values.
+// AuthMode selects the wallet-type header Polymarket expects when the
+// address signing L2 requests (the owner of APICreds.Key) isn't the address
+// actually holding the funds being traded - i.e. proxy-wallet and Gnosis
+// Safe setups. The zero value signs as a direct EOA and sends neither a
+// wallet-type header nor a separate funder address.
+type AuthMode string
+
+const (
+	AuthModeEOA        AuthMode = ""
+	AuthModeProxy      AuthMode = "POLY_PROXY"
+	AuthModeGnosisSafe AuthMode = "POLY_GNOSIS_SAFE"
+)
+
+// L2SignOptions carries the pieces of ApplyL2Headers that have sane zero
+// values.
+type L2SignOptions struct {
+	// Now returns the current time; nil defaults to time.Now. Overridable so
+	// tests can pin the timestamp that goes into the signed pre-hash string.
+	Now func() time.Time
+	// Funder is the on-chain address actually holding the funds (a proxy
+	// wallet or Gnosis Safe), sent as POLY_ADDRESS instead of address when
+	// Mode is non-empty. Ignored when Mode is AuthModeEOA.
+	Funder string
+	// Mode selects the POLY_SIGNATURE_TYPE header; the zero value
+	// (AuthModeEOA) omits it and signs as a plain EOA.
+	Mode AuthMode
+}
+
+// ApplyL2Headers signs req using Polymarket's L2 (API-key/HMAC) scheme and
+// sets the POLY_* headers it expects. body must be the exact bytes that will
+// be sent on the wire (nil for requests without a body) since it is part of
+// the signed pre-hash string. These values must NEVER be logged.
+func ApplyL2Headers(req *http.Request, creds APICreds, address string, body []byte, opts L2SignOptions) error {
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+	ts := strconv.FormatInt(now().UnixMilli(), 10)
+
+	sig, err := signL2(creds.Secret, ts, req.Method, requestPath(req), body)
+	if err != nil {
+		return err
+	}
+
+	polyAddress := address
+	if opts.Mode != AuthModeEOA && opts.Funder != "" {
+		polyAddress = opts.Funder
+	}
+
+	req.Header.Set("POLY_ADDRESS", polyAddress)
+	req.Header.Set("POLY_SIGNATURE", sig)
+	req.Header.Set("POLY_TIMESTAMP", ts)
+	req.Header.Set("POLY_API_KEY", creds.Key)
+	req.Header.Set("POLY_PASSPHRASE", creds.Passphrase)
+	if opts.Mode != AuthModeEOA {
+		req.Header.Set("POLY_SIGNATURE_TYPE", string(opts.Mode))
+	}
+	return nil
+}
+
+// requestPath is the "requestPath" component of the L2 pre-hash string: the
+// URL path plus any query string, but never the scheme/host.
+func requestPath(req *http.Request) string {
+	if req.URL.RawQuery == "" {
+		return req.URL.Path
+	}
+	return req.URL.Path + "?" + req.URL.RawQuery
+}
+
+// signL2 computes base64url(HMAC-SHA256(base64url_decode(secret), timestamp+METHOD+path+body)),
+// matching Polymarket's L2 request-signing scheme. timestamp is a
+// millisecond Unix timestamp; body is the empty string for requests without one.
+func signL2(secret, timestamp, method, path string, body []byte) (string, error) {
+	decoded, err := base64.URLEncoding.DecodeString(secret)
+	if err != nil {
+		return "", fmt.Errorf("auth: invalid L2 secret encoding: %w", err)
+	}
+
+	preHash := timestamp + strings.ToUpper(method) + path + string(body)
+
+	mac := hmac.New(sha256.New, decoded)
+	mac.Write([]byte(preHash))
+	return base64.URLEncoding.EncodeToString(mac.Sum(nil)), nil
 }
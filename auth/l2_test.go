@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// knownSecret is base64url("super-secret-key-material").
+const knownSecret = "c3VwZXItc2VjcmV0LWtleS1tYXRlcmlhbA=="
+
+// TestSignL2KnownVectors pins signL2 against pre-computed HMAC-SHA256
+// digests so a future refactor can't silently change the wire format.
+func TestSignL2KnownVectors(t *testing.T) {
+	tests := []struct {
+		name      string
+		timestamp string
+		method    string
+		path      string
+		body      []byte
+		want      string
+	}{
+		{
+			name:      "GET without body",
+			timestamp: "1700000000000",
+			method:    "GET",
+			path:      "/orders",
+			body:      nil,
+			want:      "17PLEmbcpyDbJhzMUombTXbwiRn6q9TyKV16K4L1F3E=",
+		},
+		{
+			name:      "POST with JSON body",
+			timestamp: "1700000000123",
+			method:    "POST",
+			path:      "/order",
+			body:      []byte(`{"price":"0.5"}`),
+			want:      "SV7BnRWTSSULALg_ITyLQtssNXTXpPl7h8YYt-VLHlc=",
+		},
+		{
+			name:      "lowercase method is uppercased, query string included",
+			timestamp: "1700000005000",
+			method:    "delete",
+			path:      "/orders?market=abc",
+			body:      nil,
+			want:      "8dM1AClRtRchvABPocWd_w9GAiXRMn4l32FHuHztx2g=",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := signL2(knownSecret, tt.timestamp, tt.method, tt.path, tt.body)
+			if err != nil {
+				t.Fatalf("signL2: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("signL2 = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyL2HeadersSetsExpectedHeadersAndHonorsClock(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "http://example.com/order", nil)
+	creds := APICreds{Key: "api-key", Secret: knownSecret, Passphrase: "pass"}
+	pinned := time.UnixMilli(1700000000123)
+
+	err := ApplyL2Headers(req, creds, "0xAddr", []byte(`{"price":"0.5"}`), L2SignOptions{
+		Now: func() time.Time { return pinned },
+	})
+	if err != nil {
+		t.Fatalf("ApplyL2Headers: %v", err)
+	}
+
+	if got := req.Header.Get("POLY_TIMESTAMP"); got != "1700000000123" {
+		t.Errorf("POLY_TIMESTAMP = %q, want %q", got, "1700000000123")
+	}
+	if got := req.Header.Get("POLY_ADDRESS"); got != "0xAddr" {
+		t.Errorf("POLY_ADDRESS = %q, want %q", got, "0xAddr")
+	}
+	if got := req.Header.Get("POLY_API_KEY"); got != "api-key" {
+		t.Errorf("POLY_API_KEY = %q, want %q", got, "api-key")
+	}
+	if got := req.Header.Get("POLY_PASSPHRASE"); got != "pass" {
+		t.Errorf("POLY_PASSPHRASE = %q, want %q", got, "pass")
+	}
+	if got := req.Header.Get("POLY_SIGNATURE"); got != "SV7BnRWTSSULALg_ITyLQtssNXTXpPl7h8YYt-VLHlc=" {
+		t.Errorf("POLY_SIGNATURE = %q", got)
+	}
+	if got := req.Header.Get("POLY_SIGNATURE_TYPE"); got != "" {
+		t.Errorf("expected no POLY_SIGNATURE_TYPE for plain EOA, got %q", got)
+	}
+}
+
+func TestApplyL2HeadersProxyWalletUsesFunderAddress(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/orders", nil)
+	creds := APICreds{Key: "api-key", Secret: knownSecret, Passphrase: "pass"}
+
+	err := ApplyL2Headers(req, creds, "0xSigner", nil, L2SignOptions{
+		Now:    func() time.Time { return time.UnixMilli(1700000000000) },
+		Funder: "0xFunder",
+		Mode:   AuthModeProxy,
+	})
+	if err != nil {
+		t.Fatalf("ApplyL2Headers: %v", err)
+	}
+
+	if got := req.Header.Get("POLY_ADDRESS"); got != "0xFunder" {
+		t.Errorf("POLY_ADDRESS = %q, want funder address 0xFunder", got)
+	}
+	if got := req.Header.Get("POLY_SIGNATURE_TYPE"); got != string(AuthModeProxy) {
+		t.Errorf("POLY_SIGNATURE_TYPE = %q, want %q", got, AuthModeProxy)
+	}
+}
+
+func TestHMACSignerMutateUsesInjectedClock(t *testing.T) {
+	pinned := time.UnixMilli(1700000000000)
+	signer := NewHMACSigner("0xAddr", APICreds{Key: "api-key", Secret: knownSecret, Passphrase: "pass"},
+		WithClock(func() time.Time { return pinned }))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/orders", nil)
+	if err := signer.Mutate(req, nil); err != nil {
+		t.Fatalf("Mutate: %v", err)
+	}
+
+	if got := req.Header.Get("POLY_TIMESTAMP"); got != "1700000000000" {
+		t.Errorf("POLY_TIMESTAMP = %q, want pinned clock value", got)
+	}
+}
+
+func TestHMACSignerWithGnosisSafe(t *testing.T) {
+	signer := NewHMACSigner("0xSigner", APICreds{Key: "api-key", Secret: knownSecret, Passphrase: "pass"},
+		WithGnosisSafe("0xSafe"))
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/orders", nil)
+	if err := signer.Mutate(req, nil); err != nil {
+		t.Fatalf("Mutate: %v", err)
+	}
+
+	if got := req.Header.Get("POLY_ADDRESS"); got != "0xSafe" {
+		t.Errorf("POLY_ADDRESS = %q, want safe address 0xSafe", got)
+	}
+	if got := req.Header.Get("POLY_SIGNATURE_TYPE"); got != string(AuthModeGnosisSafe) {
+		t.Errorf("POLY_SIGNATURE_TYPE = %q, want %q", got, AuthModeGnosisSafe)
+	}
+}
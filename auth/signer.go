@@ -15,9 +15,14 @@ type Signer interface {
 }
 
 type APICreds struct {
-	Key        string
-	Secret     string
-	Passphrase string
+	Key        string `json:"apiKey"`
+	Secret     string `json:"secret"`
+	Passphrase string `json:"passphrase"`
+	// Funder is the on-chain address actually holding the funds when trading
+	// through a proxy wallet or Gnosis Safe, as opposed to Key's owning EOA.
+	// It is user-supplied configuration, not part of the derive/create-api-key
+	// response, so it is never marshaled.
+	Funder string `json:"-"`
 }
 
 func (c APICreds) Redacted() APICreds {
@@ -25,11 +30,12 @@ func (c APICreds) Redacted() APICreds {
 		Key:        redaction.Redact(c.Key),
 		Secret:     redaction.Redact(c.Secret),
 		Passphrase: redaction.Redact(c.Passphrase),
+		Funder:     c.Funder,
 	}
 }
 
 // String implements fmt.Stringer for safe logging & never returns raw secrets
 func (c APICreds) String() string {
 	r := c.Redacted()
-	return fmt.Sprintf("APICreds{Key=%q Secret=%q Passphrase=%q}", r.Key, r.Secret, r.Passphrase)
+	return fmt.Sprintf("APICreds{Key=%q Secret=%q Passphrase=%q Funder=%q}", r.Key, r.Secret, r.Passphrase, r.Funder)
 }
@@ -0,0 +1,174 @@
+// Package batcher fans a large slice of per-item requests out into
+// bounded-size, concurrently-fetched chunks, coalescing identical in-flight
+// chunk requests and merging partial failures into a typed multi-error
+// while still returning whatever chunks did succeed.
+//
+// It exists because endpoints like /midpoints, /prices and /spreads accept
+// an array of lookups per call but cap how many can go in one request:
+// watching hundreds of tokens means splitting into multiple calls, and
+// naively doing that from many goroutines risks both exceeding those caps
+// and re-fetching the same tokens concurrently.
+package batcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Options configures how Run splits and fans out work.
+type Options struct {
+	// ChunkSize is the max number of items sent in a single underlying
+	// request. Zero uses DefaultOptions' value.
+	ChunkSize int
+	// Workers is the max number of chunk requests in flight at once. Zero
+	// uses DefaultOptions' value.
+	Workers int
+}
+
+// DefaultOptions returns conservative defaults: chunks of 200 items, 8
+// concurrent chunk requests.
+func DefaultOptions() Options {
+	return Options{ChunkSize: 200, Workers: 8}
+}
+
+func (o Options) withDefaults() Options {
+	if o.ChunkSize <= 0 {
+		o.ChunkSize = 200
+	}
+	if o.Workers <= 0 {
+		o.Workers = 8
+	}
+	return o
+}
+
+// MultiError aggregates the errors from chunks that failed. Run still
+// returns whatever chunks succeeded alongside a non-nil *MultiError, so
+// callers that want partial results don't need to discard them.
+type MultiError struct {
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("batcher: %d chunk(s) failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func (e *MultiError) Unwrap() []error { return e.Errors }
+
+// Group coalesces concurrent identical chunk fetches keyed by a caller-
+// supplied string: the first caller for a given key actually invokes fn,
+// and any concurrent callers for the same key block on and reuse its
+// result instead of issuing a duplicate request. It is safe for concurrent
+// use and intended to be held for the lifetime of a client.
+type Group[V any] struct {
+	mu       sync.Mutex
+	inflight map[string]*call[V]
+}
+
+type call[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// NewGroup returns an empty Group.
+func NewGroup[V any]() *Group[V] {
+	return &Group[V]{inflight: make(map[string]*call[V])}
+}
+
+// Do executes fn for the first caller of key; concurrent callers for the
+// same key wait for and share that call's result.
+func (g *Group[V]) Do(key string, fn func() (V, error)) (V, error) {
+	g.mu.Lock()
+	if c, ok := g.inflight[key]; ok {
+		g.mu.Unlock()
+		<-c.done
+		return c.val, c.err
+	}
+	c := &call[V]{done: make(chan struct{})}
+	g.inflight[key] = c
+	g.mu.Unlock()
+
+	c.val, c.err = fn()
+	close(c.done)
+
+	g.mu.Lock()
+	delete(g.inflight, key)
+	g.mu.Unlock()
+
+	return c.val, c.err
+}
+
+// Run splits items into chunks of at most opts.ChunkSize, fetches each
+// chunk via fn (deduped across identical concurrent chunks via group),
+// bounded by opts.Workers concurrent fetches, and merges the per-chunk
+// result maps into one. Chunk failures are collected into a *MultiError
+// without discarding the maps from chunks that did succeed.
+func Run[T any, V any](
+	ctx context.Context,
+	items []T,
+	opts Options,
+	group *Group[map[string]V],
+	chunkKey func(chunk []T) string,
+	fn func(ctx context.Context, chunk []T) (map[string]V, error),
+) (map[string]V, error) {
+	opts = opts.withDefaults()
+	chunks := splitChunks(items, opts.ChunkSize)
+
+	type chunkResult struct {
+		vals map[string]V
+		err  error
+	}
+	results := make([]chunkResult, len(chunks))
+
+	sem := make(chan struct{}, opts.Workers)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chunk []T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			key := chunkKey(chunk)
+			vals, err := group.Do(key, func() (map[string]V, error) { return fn(ctx, chunk) })
+			results[i] = chunkResult{vals: vals, err: err}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	merged := make(map[string]V)
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		for k, v := range r.vals {
+			merged[k] = v
+		}
+	}
+	if len(errs) > 0 {
+		return merged, &MultiError{Errors: errs}
+	}
+	return merged, nil
+}
+
+func splitChunks[T any](items []T, size int) [][]T {
+	if len(items) == 0 {
+		return nil
+	}
+	chunks := make([][]T, 0, (len(items)+size-1)/size)
+	for i := 0; i < len(items); i += size {
+		end := i + size
+		if end > len(items) {
+			end = len(items)
+		}
+		chunks = append(chunks, items[i:end])
+	}
+	return chunks
+}
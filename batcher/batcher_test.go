@@ -0,0 +1,143 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func chunkKeyJoin(chunk []string) string { return strings.Join(chunk, ",") }
+
+func TestRun_SplitsLargeInputAcrossChunksAndMergesResults(t *testing.T) {
+	items := make([]string, 1000)
+	for i := range items {
+		items[i] = strconv.Itoa(i)
+	}
+
+	var calls int32
+	fn := func(ctx context.Context, chunk []string) (map[string]int, error) {
+		atomic.AddInt32(&calls, 1)
+		out := make(map[string]int, len(chunk))
+		for _, id := range chunk {
+			n, _ := strconv.Atoi(id)
+			out[id] = n
+		}
+		return out, nil
+	}
+
+	merged, err := Run(context.Background(), items, Options{ChunkSize: 37, Workers: 8}, NewGroup[map[string]int](), chunkKeyJoin, fn)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(merged) != 1000 {
+		t.Fatalf("expected 1000 merged entries, got %d", len(merged))
+	}
+	for i := 0; i < 1000; i++ {
+		if merged[strconv.Itoa(i)] != i {
+			t.Fatalf("entry %d: got %d", i, merged[strconv.Itoa(i)])
+		}
+	}
+	wantCalls := (1000 + 37 - 1) / 37
+	if int(calls) != wantCalls {
+		t.Fatalf("expected %d chunk calls, got %d", wantCalls, calls)
+	}
+}
+
+func TestRun_PartialChunkFailureReturnsMultiErrorAndSuccessfulEntries(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+	fn := func(ctx context.Context, chunk []string) (map[string]int, error) {
+		if chunk[0] == "c" {
+			return nil, errors.New("boom")
+		}
+		out := make(map[string]int, len(chunk))
+		for i, id := range chunk {
+			out[id] = i
+		}
+		return out, nil
+	}
+
+	merged, err := Run(context.Background(), items, Options{ChunkSize: 2, Workers: 2}, NewGroup[map[string]int](), chunkKeyJoin, fn)
+	if err == nil {
+		t.Fatal("expected a MultiError")
+	}
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("expected errors.As to find *MultiError, got %T", err)
+	}
+	if len(multiErr.Errors) != 1 {
+		t.Fatalf("expected exactly 1 failed chunk, got %d", len(multiErr.Errors))
+	}
+	if _, ok := merged["a"]; !ok {
+		t.Error("expected successful chunk's entries to still be present")
+	}
+	if _, ok := merged["c"]; ok {
+		t.Error("expected failed chunk's entries to be absent")
+	}
+}
+
+func TestGroup_CoalescesConcurrentIdenticalCalls(t *testing.T) {
+	g := NewGroup[int]()
+	var calls int32
+
+	const n = 20
+	entered := make(chan struct{}, n)
+	release := make(chan struct{})
+	results := make(chan int, n)
+	start := make(chan struct{})
+
+	for i := 0; i < n; i++ {
+		go func() {
+			<-start
+			v, err := g.Do("shared-key", func() (int, error) {
+				atomic.AddInt32(&calls, 1)
+				entered <- struct{}{}
+				<-release // hold the call open until every goroutine has arrived
+				return 42, nil
+			})
+			if err != nil {
+				t.Errorf("Do: %v", err)
+			}
+			results <- v
+		}()
+	}
+	close(start)
+
+	// Wait for the single underlying call to start, then give every other
+	// goroutine a generous window to call Do and join it before releasing.
+	<-entered
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	for i := 0; i < n; i++ {
+		if got := <-results; got != 42 {
+			t.Fatalf("expected 42, got %d", got)
+		}
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected exactly 1 underlying call, got %d", calls)
+	}
+}
+
+func TestGroup_DistinctKeysRunIndependently(t *testing.T) {
+	g := NewGroup[int]()
+	var calls int32
+
+	for i := 0; i < 5; i++ {
+		v, err := g.Do(fmt.Sprintf("key-%d", i), func() (int, error) {
+			atomic.AddInt32(&calls, 1)
+			return i, nil
+		})
+		if err != nil || v != i {
+			t.Fatalf("Do(%d) = %d, %v", i, v, err)
+		}
+	}
+	if calls != 5 {
+		t.Fatalf("expected 5 underlying calls, got %d", calls)
+	}
+}
@@ -0,0 +1,347 @@
+// Package book maintains a client-side order book that's kept in sync by
+// replaying the Polymarket WebSocket market channel's "book" snapshots and
+// "price_change" deltas, so callers get a live bid/ask ladder without
+// re-deriving one from the wire events themselves.
+package book
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/shopspring/decimal"
+	"github.com/star-gazer111/poly-go-clob-client/types"
+	"github.com/star-gazer111/poly-go-clob-client/wsstream"
+)
+
+// EventKind identifies what triggered a BookEvent.
+type EventKind string
+
+const (
+	// EventSnapshot fires after a full "book" snapshot is applied.
+	EventSnapshot EventKind = "snapshot"
+	// EventDelta fires after a single "price_change" level update is applied.
+	EventDelta EventKind = "delta"
+)
+
+// BookEvent is delivered to a Subscribe handler after every ladder update.
+type BookEvent struct {
+	Kind     EventKind
+	AssetID  string
+	Market   string
+	BestBid  decimal.Decimal
+	BestAsk  decimal.Decimal
+	Desynced bool
+}
+
+// LocalBook is a materialized bid/ask ladder for a single token, built by
+// replaying wsstream market-channel events. It is safe for concurrent use.
+type LocalBook struct {
+	tokenID string
+
+	mu          sync.RWMutex
+	market      string
+	bids        map[string]decimal.Decimal // price string -> size
+	asks        map[string]decimal.Decimal
+	timestamp   string
+	serverHash  string
+	hasSnapshot bool
+	desynced    bool
+
+	onEvent func(BookEvent)
+	closeFn func() error
+}
+
+// NewLocalBook returns an empty book for tokenID. It starts desynced until
+// the first snapshot is applied via ApplyBookUpdate.
+func NewLocalBook(tokenID string) *LocalBook {
+	return &LocalBook{
+		tokenID:  tokenID,
+		bids:     make(map[string]decimal.Decimal),
+		asks:     make(map[string]decimal.Decimal),
+		desynced: true,
+	}
+}
+
+// TokenID returns the asset ID this book tracks.
+func (b *LocalBook) TokenID() string { return b.tokenID }
+
+// Desynced reports whether the ladder should be treated as stale until the
+// next successful ApplyBookUpdate (or an explicit ResetFromSummary).
+//
+// This is NOT a cryptographic check against Polymarket's server-reported
+// book hash: that hashing algorithm isn't published and this package does
+// not reimplement it, so a locally computed hash can never be meaningfully
+// compared against ev.Hash. ev.Hash is only retained (see Snapshot) for
+// callers who want to forward it elsewhere. Desynced instead tracks
+// conditions LocalBook can actually detect on its own: no snapshot has been
+// applied yet, or a price_change arrived for a different market than the
+// one the current ladder was built from.
+func (b *LocalBook) Desynced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.desynced
+}
+
+// Subscribe registers fn to be invoked after every applied update. Only one
+// handler is kept at a time; calling Subscribe again replaces it.
+func (b *LocalBook) Subscribe(fn func(BookEvent)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onEvent = fn
+}
+
+// ApplyBookUpdate replaces the ladder with a full snapshot. A snapshot is
+// authoritative by definition, so this always clears Desynced; it returns
+// true unless ev is rejected outright (currently it never is, but the bool
+// return is kept so callers don't need to change if that becomes possible).
+func (b *LocalBook) ApplyBookUpdate(ev wsstream.BookUpdate) bool {
+	b.mu.Lock()
+
+	b.market = ev.Market
+	b.timestamp = ev.Timestamp
+	b.bids = levelsToMap(ev.Bids)
+	b.asks = levelsToMap(ev.Asks)
+	b.serverHash = ev.Hash
+	b.hasSnapshot = true
+	b.desynced = false
+
+	out := b.eventLocked(EventSnapshot)
+	fn := b.onEvent
+	b.mu.Unlock()
+
+	if fn != nil {
+		fn(out)
+	}
+	return true
+}
+
+// ApplyPriceChange applies a single incremental level update and returns
+// whether it could be trusted: false means ev arrived before any snapshot,
+// or for a different market than the one the current ladder was built from,
+// so the ladder is now Desynced and should be resynced from a REST OrderBook
+// snapshot via ResetFromSummary. See Desynced's doc comment for why this
+// isn't a hash comparison against the server.
+func (b *LocalBook) ApplyPriceChange(ev wsstream.PriceChange) bool {
+	b.mu.Lock()
+
+	if !b.hasSnapshot || (b.market != "" && ev.Market != "" && ev.Market != b.market) {
+		b.desynced = true
+		out := b.eventLocked(EventDelta)
+		fn := b.onEvent
+		b.mu.Unlock()
+		if fn != nil {
+			fn(out)
+		}
+		return false
+	}
+
+	b.timestamp = ev.Timestamp
+	side := strings.ToUpper(ev.Side)
+	ladder := b.bids
+	if side == "SELL" {
+		ladder = b.asks
+	}
+	applyLevel(ladder, ev.Price, ev.Size)
+	b.serverHash = ev.Hash
+
+	out := b.eventLocked(EventDelta)
+	fn := b.onEvent
+	b.mu.Unlock()
+
+	if fn != nil {
+		fn(out)
+	}
+	return true
+}
+
+// ResetFromSummary rebuilds the ladder from a REST OrderBook response,
+// clearing the desync flag. Use this after ApplyPriceChange reports a
+// mismatch.
+func (b *LocalBook) ResetFromSummary(resp *types.OrderBookSummaryResponse) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.market = resp.Market
+	b.bids = summaryToMap(resp.Bids)
+	b.asks = summaryToMap(resp.Asks)
+	if resp.Hash != nil {
+		b.serverHash = *resp.Hash
+	} else {
+		b.serverHash = ""
+	}
+	b.hasSnapshot = true
+	b.desynced = false
+}
+
+// BestBid returns the highest bid price and whether the book has any bids.
+func (b *LocalBook) BestBid() (decimal.Decimal, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return bestPrice(b.bids, true)
+}
+
+// BestAsk returns the lowest ask price and whether the book has any asks.
+func (b *LocalBook) BestAsk() (decimal.Decimal, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return bestPrice(b.asks, false)
+}
+
+// Midpoint returns the mean of BestBid and BestAsk, and false if either side is empty.
+func (b *LocalBook) Midpoint() (decimal.Decimal, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	bid, ok := bestPrice(b.bids, true)
+	if !ok {
+		return decimal.Zero, false
+	}
+	ask, ok := bestPrice(b.asks, false)
+	if !ok {
+		return decimal.Zero, false
+	}
+	return bid.Add(ask).Div(decimal.NewFromInt(2)), true
+}
+
+// Spread returns BestAsk minus BestBid, and false if either side is empty.
+func (b *LocalBook) Spread() (decimal.Decimal, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	bid, ok := bestPrice(b.bids, true)
+	if !ok {
+		return decimal.Zero, false
+	}
+	ask, ok := bestPrice(b.asks, false)
+	if !ok {
+		return decimal.Zero, false
+	}
+	return ask.Sub(bid), true
+}
+
+// Snapshot returns the current ladder in the same shape as the REST order
+// book endpoint, sorted best-to-worst on each side.
+func (b *LocalBook) Snapshot() *types.OrderBookSummaryResponse {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var hash *string
+	if b.serverHash != "" {
+		h := b.serverHash
+		hash = &h
+	}
+
+	return &types.OrderBookSummaryResponse{
+		Market:  b.market,
+		AssetID: b.tokenID,
+		Hash:    hash,
+		Bids:    sortedLevels(b.bids, true),
+		Asks:    sortedLevels(b.asks, false),
+	}
+}
+
+// Close releases any resources (e.g. the underlying WS connection) wired up
+// by the code that constructed this book. It is a no-op if none were set.
+func (b *LocalBook) Close() error {
+	b.mu.RLock()
+	closeFn := b.closeFn
+	b.mu.RUnlock()
+	if closeFn == nil {
+		return nil
+	}
+	return closeFn()
+}
+
+// SetCloseFunc wires up the teardown hook invoked by Close. It exists so
+// wiring helpers (e.g. clob.PublicClient.SubscribeBook) can attach the
+// lifecycle of the stream connection they opened on the caller's behalf.
+func (b *LocalBook) SetCloseFunc(fn func() error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closeFn = fn
+}
+
+func (b *LocalBook) eventLocked(kind EventKind) BookEvent {
+	bestBid, _ := bestPrice(b.bids, true)
+	bestAsk, _ := bestPrice(b.asks, false)
+	return BookEvent{
+		Kind:     kind,
+		AssetID:  b.tokenID,
+		Market:   b.market,
+		BestBid:  bestBid,
+		BestAsk:  bestAsk,
+		Desynced: b.desynced,
+	}
+}
+
+func levelsToMap(levels []wsstream.PriceLevel) map[string]decimal.Decimal {
+	m := make(map[string]decimal.Decimal, len(levels))
+	for _, lvl := range levels {
+		applyLevel(m, lvl.Price, lvl.Size)
+	}
+	return m
+}
+
+func summaryToMap(levels []types.OrderSummary) map[string]decimal.Decimal {
+	m := make(map[string]decimal.Decimal, len(levels))
+	for _, lvl := range levels {
+		price := decimal.NewFromFloat(lvl.Price)
+		size := decimal.NewFromFloat(lvl.Size)
+		if size.IsZero() {
+			continue
+		}
+		m[price.String()] = size
+	}
+	return m
+}
+
+// applyLevel sets or removes a single price level in-place: a zero (or
+// unparsable) size deletes the level, matching how price_change deltas
+// represent level removal on the wire.
+func applyLevel(m map[string]decimal.Decimal, priceStr, sizeStr string) {
+	price, err := decimal.NewFromString(priceStr)
+	if err != nil {
+		return
+	}
+	size, err := decimal.NewFromString(sizeStr)
+	if err != nil || size.IsZero() {
+		delete(m, price.String())
+		return
+	}
+	m[price.String()] = size
+}
+
+func bestPrice(m map[string]decimal.Decimal, highest bool) (decimal.Decimal, bool) {
+	var best decimal.Decimal
+	found := false
+	for priceStr := range m {
+		price, err := decimal.NewFromString(priceStr)
+		if err != nil {
+			continue
+		}
+		if !found || (highest && price.GreaterThan(best)) || (!highest && price.LessThan(best)) {
+			best = price
+			found = true
+		}
+	}
+	return best, found
+}
+
+func sortedLevels(m map[string]decimal.Decimal, descending bool) []types.OrderSummary {
+	out := make([]types.OrderSummary, 0, len(m))
+	for priceStr, size := range m {
+		price, err := decimal.NewFromString(priceStr)
+		if err != nil {
+			continue
+		}
+		p, _ := price.Float64()
+		s, _ := size.Float64()
+		out = append(out, types.OrderSummary{Price: p, Size: s})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if descending {
+			return out[i].Price > out[j].Price
+		}
+		return out[i].Price < out[j].Price
+	})
+	return out
+}
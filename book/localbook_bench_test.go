@@ -0,0 +1,31 @@
+package book
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/star-gazer111/poly-go-clob-client/wsstream"
+)
+
+// BenchmarkLocalBook_ApplyPriceChange exercises the hot path for a streaming
+// book: it should comfortably clear 10k updates/sec (ns/op well under
+// 100,000) on typical hardware.
+func BenchmarkLocalBook_ApplyPriceChange(b *testing.B) {
+	lb := NewLocalBook("tok1")
+	lb.ApplyBookUpdate(wsstream.BookUpdate{
+		AssetID: "tok1",
+		Bids:    []wsstream.PriceLevel{{Price: "0.40", Size: "100"}},
+		Asks:    []wsstream.PriceLevel{{Price: "0.60", Size: "100"}},
+	})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		price := "0." + strconv.Itoa(10+i%40)
+		lb.ApplyPriceChange(wsstream.PriceChange{
+			AssetID: "tok1",
+			Side:    "BUY",
+			Price:   price,
+			Size:    "5",
+		})
+	}
+}
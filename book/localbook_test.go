@@ -0,0 +1,168 @@
+package book
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/star-gazer111/poly-go-clob-client/types"
+	"github.com/star-gazer111/poly-go-clob-client/wsstream"
+)
+
+func TestLocalBook_ApplyBookUpdateBuildsLadder(t *testing.T) {
+	snap := wsstream.BookUpdate{
+		AssetID: "tok1",
+		Market:  "m1",
+		Bids: []wsstream.PriceLevel{
+			{Price: "0.40", Size: "100"},
+			{Price: "0.45", Size: "50"},
+		},
+		Asks: []wsstream.PriceLevel{
+			{Price: "0.55", Size: "30"},
+			{Price: "0.50", Size: "20"},
+		},
+		Hash: "whatever-the-server-sent",
+	}
+
+	lb := NewLocalBook("tok1")
+	if synced := lb.ApplyBookUpdate(snap); !synced {
+		t.Fatal("expected ApplyBookUpdate to report applied")
+	}
+	if lb.Desynced() {
+		t.Fatal("expected book to be synced after a snapshot, regardless of ev.Hash")
+	}
+
+	bid, ok := lb.BestBid()
+	if !ok || !bid.Equal(mustDecimal("0.45")) {
+		t.Fatalf("BestBid = %v, %v", bid, ok)
+	}
+	ask, ok := lb.BestAsk()
+	if !ok || !ask.Equal(mustDecimal("0.50")) {
+		t.Fatalf("BestAsk = %v, %v", ask, ok)
+	}
+
+	mid, ok := lb.Midpoint()
+	if !ok || !mid.Equal(mustDecimal("0.475")) {
+		t.Fatalf("Midpoint = %v, %v", mid, ok)
+	}
+	spread, ok := lb.Spread()
+	if !ok || !spread.Equal(mustDecimal("0.05")) {
+		t.Fatalf("Spread = %v, %v", spread, ok)
+	}
+
+	snap2 := lb.Snapshot()
+	if snap2.Hash == nil || *snap2.Hash != "whatever-the-server-sent" {
+		t.Fatalf("expected ev.Hash to be retained verbatim for callers, got %v", snap2.Hash)
+	}
+}
+
+func TestLocalBook_ApplyPriceChangeBeforeAnySnapshotFlagsDesync(t *testing.T) {
+	lb := NewLocalBook("tok1")
+	synced := lb.ApplyPriceChange(wsstream.PriceChange{
+		AssetID: "tok1",
+		Side:    "BUY",
+		Price:   "0.40",
+		Size:    "100",
+	})
+	if synced {
+		t.Fatal("expected a price_change before any snapshot to report desync")
+	}
+	if !lb.Desynced() {
+		t.Fatal("expected Desynced() to be true")
+	}
+}
+
+func TestLocalBook_ApplyPriceChangeForDifferentMarketFlagsDesync(t *testing.T) {
+	lb := NewLocalBook("tok1")
+	lb.ApplyBookUpdate(wsstream.BookUpdate{
+		AssetID: "tok1",
+		Market:  "m1",
+		Bids:    []wsstream.PriceLevel{{Price: "0.40", Size: "100"}},
+	})
+
+	synced := lb.ApplyPriceChange(wsstream.PriceChange{
+		AssetID: "tok1",
+		Market:  "m2",
+		Side:    "BUY",
+		Price:   "0.41",
+		Size:    "10",
+	})
+	if synced {
+		t.Fatal("expected a price_change for a different market to report desync")
+	}
+	if !lb.Desynced() {
+		t.Fatal("expected Desynced() to be true")
+	}
+}
+
+func TestLocalBook_ApplyPriceChangeUpdatesAndRemovesLevels(t *testing.T) {
+	lb := NewLocalBook("tok1")
+	lb.ApplyBookUpdate(wsstream.BookUpdate{
+		AssetID: "tok1",
+		Bids:    []wsstream.PriceLevel{{Price: "0.40", Size: "100"}},
+		Asks:    []wsstream.PriceLevel{{Price: "0.60", Size: "100"}},
+	})
+
+	lb.ApplyPriceChange(wsstream.PriceChange{
+		AssetID: "tok1",
+		Side:    "BUY",
+		Price:   "0.42",
+		Size:    "10",
+	})
+	bid, ok := lb.BestBid()
+	if !ok || !bid.Equal(mustDecimal("0.42")) {
+		t.Fatalf("expected new best bid 0.42, got %v, %v", bid, ok)
+	}
+
+	lb.ApplyPriceChange(wsstream.PriceChange{
+		AssetID: "tok1",
+		Side:    "BUY",
+		Price:   "0.42",
+		Size:    "0",
+	})
+	bid, ok = lb.BestBid()
+	if !ok || !bid.Equal(mustDecimal("0.40")) {
+		t.Fatalf("expected removal to fall back to 0.40, got %v, %v", bid, ok)
+	}
+}
+
+func TestLocalBook_ResetFromSummaryClearsDesync(t *testing.T) {
+	lb := NewLocalBook("tok1")
+	lb.ApplyPriceChange(wsstream.PriceChange{AssetID: "tok1", Side: "BUY", Price: "0.4", Size: "10"})
+	if !lb.Desynced() {
+		t.Fatal("expected desync from a price_change before any snapshot")
+	}
+
+	lb.ResetFromSummary(&types.OrderBookSummaryResponse{
+		Market: "m1",
+		Bids:   []types.OrderSummary{{Price: 0.4, Size: 100}},
+		Asks:   []types.OrderSummary{{Price: 0.6, Size: 100}},
+	})
+	if lb.Desynced() {
+		t.Fatal("expected ResetFromSummary to clear desync when no server hash is present")
+	}
+	snap := lb.Snapshot()
+	if len(snap.Bids) != 1 || len(snap.Asks) != 1 {
+		t.Fatalf("unexpected snapshot after reset: %+v", snap)
+	}
+}
+
+func TestLocalBook_SubscribeReceivesEvents(t *testing.T) {
+	lb := NewLocalBook("tok1")
+
+	var events []BookEvent
+	lb.Subscribe(func(ev BookEvent) { events = append(events, ev) })
+
+	lb.ApplyBookUpdate(wsstream.BookUpdate{AssetID: "tok1", Bids: []wsstream.PriceLevel{{Price: "0.4", Size: "10"}}})
+	lb.ApplyPriceChange(wsstream.PriceChange{AssetID: "tok1", Side: "BUY", Price: "0.41", Size: "5"})
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].Kind != EventSnapshot || events[1].Kind != EventDelta {
+		t.Fatalf("unexpected event kinds: %+v", events)
+	}
+}
+
+func mustDecimal(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
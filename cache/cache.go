@@ -0,0 +1,49 @@
+// Package cache provides a pluggable response cache for PublicClient's
+// public GET endpoints (see clob.WithCache), plus a ready-to-use in-memory
+// implementation. Bring your own store (Redis, memcached, ...) by
+// implementing Cache; see the rediscache subpackage for an example adapter.
+package cache
+
+import (
+	"path"
+	"time"
+)
+
+// Cache is a keyed byte-slice store with per-entry TTLs. Implementations
+// must be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached value for key and whether it was found (a miss
+	// and an expired entry are indistinguishable to the caller).
+	Get(key string) ([]byte, bool)
+	// Set stores value under key, to be evicted after ttl.
+	Set(key string, value []byte, ttl time.Duration)
+	// Purge removes every entry.
+	Purge()
+}
+
+// CacheRule matches a request by method and path pattern, and specifies how
+// long a matching response may be served from cache.
+type CacheRule struct {
+	// Method is the HTTP method to match (e.g. http.MethodGet). Empty
+	// matches any method.
+	Method string
+	// PathPattern is a path.Match glob against the request's URL path, e.g.
+	// "/markets/*" or the literal "/book".
+	PathPattern string
+	// TTL is how long a response matching this rule may be cached.
+	TTL time.Duration
+}
+
+// MatchRules returns the TTL of the first rule in rules matching method and
+// reqPath, and whether any rule matched.
+func MatchRules(rules []CacheRule, method, reqPath string) (time.Duration, bool) {
+	for _, r := range rules {
+		if r.Method != "" && r.Method != method {
+			continue
+		}
+		if matched, err := path.Match(r.PathPattern, reqPath); err == nil && matched {
+			return r.TTL, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultShardCount    = 16
+	defaultSweepInterval = 30 * time.Second
+)
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+type shard struct {
+	mu   sync.RWMutex
+	data map[string]memoryEntry
+}
+
+// MemoryCache is an in-process Cache backed by a sharded map (to reduce lock
+// contention across concurrent Get/Set calls) with a background goroutine
+// that sweeps expired entries every SweepInterval.
+type MemoryCache struct {
+	shards []*shard
+
+	sweepInterval time.Duration
+	stop          chan struct{}
+	stopOnce      sync.Once
+}
+
+// MemoryCacheOption configures a MemoryCache.
+type MemoryCacheOption func(*memoryCacheConfig)
+
+type memoryCacheConfig struct {
+	shardCount    int
+	sweepInterval time.Duration
+}
+
+// WithShardCount overrides the number of shards the map is split across
+// (default 16). Higher counts reduce lock contention under heavy concurrent
+// use at the cost of a little more memory.
+func WithShardCount(n int) MemoryCacheOption {
+	return func(c *memoryCacheConfig) {
+		if n > 0 {
+			c.shardCount = n
+		}
+	}
+}
+
+// WithSweepInterval overrides how often the background goroutine scans for
+// and evicts expired entries (default 30s).
+func WithSweepInterval(d time.Duration) MemoryCacheOption {
+	return func(c *memoryCacheConfig) {
+		if d > 0 {
+			c.sweepInterval = d
+		}
+	}
+}
+
+// NewMemoryCache builds a MemoryCache and starts its background sweep
+// goroutine. Call Close to stop it.
+func NewMemoryCache(opts ...MemoryCacheOption) *MemoryCache {
+	cfg := memoryCacheConfig{shardCount: defaultShardCount, sweepInterval: defaultSweepInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	shards := make([]*shard, cfg.shardCount)
+	for i := range shards {
+		shards[i] = &shard{data: make(map[string]memoryEntry)}
+	}
+
+	c := &MemoryCache{
+		shards:        shards,
+		sweepInterval: cfg.sweepInterval,
+		stop:          make(chan struct{}),
+	}
+	go c.sweepLoop()
+	return c
+}
+
+func (c *MemoryCache) shardFor(key string) *shard {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return c.shards[h.Sum32()%uint32(len(c.shards))]
+}
+
+// Get returns key's value if present and not expired.
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	s := c.shardFor(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.data[key]
+	if !ok || time.Now().After(e.expiresAt) {
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Set stores value under key, to be evicted after ttl (or at the next sweep
+// on or after that, whichever is later).
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	s := c.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = memoryEntry{value: value, expiresAt: time.Now().Add(ttl)}
+}
+
+// Purge removes every entry from every shard.
+func (c *MemoryCache) Purge() {
+	for _, s := range c.shards {
+		s.mu.Lock()
+		s.data = make(map[string]memoryEntry)
+		s.mu.Unlock()
+	}
+}
+
+// Close stops the background sweep goroutine. Safe to call multiple times.
+func (c *MemoryCache) Close() {
+	c.stopOnce.Do(func() { close(c.stop) })
+}
+
+func (c *MemoryCache) sweepLoop() {
+	ticker := time.NewTicker(c.sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			c.sweep()
+		}
+	}
+}
+
+func (c *MemoryCache) sweep() {
+	now := time.Now()
+	for _, s := range c.shards {
+		s.mu.Lock()
+		for k, e := range s.data {
+			if now.After(e.expiresAt) {
+				delete(s.data, k)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
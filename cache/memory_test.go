@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryCache_SetThenGetHits(t *testing.T) {
+	c := NewMemoryCache()
+	defer c.Close()
+
+	c.Set("k", []byte("v"), time.Minute)
+
+	got, ok := c.Get("k")
+	if !ok || string(got) != "v" {
+		t.Fatalf("Get(k) = %q, %v; want \"v\", true", got, ok)
+	}
+}
+
+func TestMemoryCache_GetMissesUnknownKey(t *testing.T) {
+	c := NewMemoryCache()
+	defer c.Close()
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("expected a miss for an unset key")
+	}
+}
+
+func TestMemoryCache_EntryExpiresAfterTTL(t *testing.T) {
+	c := NewMemoryCache()
+	defer c.Close()
+
+	c.Set("k", []byte("v"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("k"); ok {
+		t.Fatal("expected the entry to have expired")
+	}
+}
+
+func TestMemoryCache_SweepEvictsExpiredEntries(t *testing.T) {
+	c := NewMemoryCache(WithSweepInterval(5 * time.Millisecond))
+	defer c.Close()
+
+	c.Set("k", []byte("v"), time.Millisecond)
+
+	s := c.shardFor("k")
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		s.mu.RLock()
+		empty := len(s.data) == 0
+		s.mu.RUnlock()
+		if empty {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("expected the sweep goroutine to evict the expired entry")
+}
+
+func TestMemoryCache_PurgeRemovesEverything(t *testing.T) {
+	c := NewMemoryCache()
+	defer c.Close()
+
+	c.Set("a", []byte("1"), time.Minute)
+	c.Set("b", []byte("2"), time.Minute)
+	c.Purge()
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected a to be purged")
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected b to be purged")
+	}
+}
+
+func TestMatchRules_MatchesMethodAndGlobPattern(t *testing.T) {
+	rules := []CacheRule{
+		{Method: "GET", PathPattern: "/markets/*", TTL: 30 * time.Second},
+		{Method: "GET", PathPattern: "/book", TTL: time.Second},
+	}
+
+	if ttl, ok := MatchRules(rules, "GET", "/markets/0x123"); !ok || ttl != 30*time.Second {
+		t.Fatalf("MatchRules(/markets/0x123) = %v, %v; want 30s, true", ttl, ok)
+	}
+	if ttl, ok := MatchRules(rules, "GET", "/book"); !ok || ttl != time.Second {
+		t.Fatalf("MatchRules(/book) = %v, %v; want 1s, true", ttl, ok)
+	}
+	if _, ok := MatchRules(rules, "GET", "/ping"); ok {
+		t.Fatal("expected no rule to match /ping")
+	}
+	if _, ok := MatchRules(rules, "POST", "/book"); ok {
+		t.Fatal("expected method mismatch to prevent a match")
+	}
+}
@@ -0,0 +1,61 @@
+// Package rediscache is an example cache.Cache adapter for a Redis-backed
+// store, for users who want a response cache shared across processes
+// instead of cache.MemoryCache's single-process, in-memory one. It does not
+// depend on any particular Redis client library: callers supply their own
+// via the Client interface below, typically as a thin wrapper around their
+// Redis client of choice.
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/star-gazer111/poly-go-clob-client/cache"
+)
+
+// ErrNotFound is returned by Client.Get when key has no value (or has
+// expired), distinguishing a cache miss from a connection error.
+var ErrNotFound = errors.New("rediscache: key not found")
+
+// Client is the minimal subset of a Redis client this cache needs.
+type Client interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	FlushDB(ctx context.Context) error
+}
+
+// Cache adapts a Client to cache.Cache. Unlike cache.MemoryCache, every call
+// here blocks on network I/O, so it suits a cache shared across multiple
+// PublicClient instances/processes rather than the single-process hot path.
+type Cache struct {
+	client Client
+}
+
+var _ cache.Cache = (*Cache)(nil)
+
+// New wraps client as a cache.Cache.
+func New(client Client) *Cache {
+	return &Cache{client: client}
+}
+
+// Get fetches key, treating ErrNotFound (or any other error) as a miss.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	v, err := c.client.Get(context.Background(), key)
+	if err != nil {
+		return nil, false
+	}
+	return v, true
+}
+
+// Set stores value under key with the given ttl, swallowing errors the same
+// way a cache miss is swallowed on Get - a cache is an optimization, and a
+// failed write just means the next Get also misses.
+func (c *Cache) Set(key string, value []byte, ttl time.Duration) {
+	_ = c.client.Set(context.Background(), key, value, ttl)
+}
+
+// Purge flushes the entire database the client is connected to.
+func (c *Cache) Purge() {
+	_ = c.client.FlushDB(context.Background())
+}
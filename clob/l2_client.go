@@ -1,20 +1,176 @@
 package clob
 
-import "github.com/star-gazer111/poly-go-clob-client/auth"
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
 
+	"github.com/star-gazer111/poly-go-clob-client/auth"
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+// L2Client is an L1Client that additionally signs every request with
+// Polymarket's L2 (API-key/HMAC) scheme, unlocking the private endpoints:
+// orders, cancels and trade history.
 type L2Client struct {
-	*PublicClient
-	creds auth.APICreds
+	*L1Client
+	signer *auth.HMACSigner
+}
+
+// NewL2Client builds an L2Client for address, authenticated both at L1 (via
+// l1Signer, used for EIP-712 flows like DeriveAPICreds) and L2 (via creds,
+// used to sign every REST call made through this client).
+func NewL2Client(baseURL string, l1Signer auth.Signer, creds auth.APICreds, opts ...PublicClientOption) (*L2Client, error) {
+	l1, err := NewL1Client(baseURL, l1Signer, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := auth.NewHMACSigner(l1Signer.Address().Hex(), creds)
+	l1.PublicClient.transport = l1.PublicClient.transport.WithMutate(signer.Mutate)
+
+	return &L2Client{L1Client: l1, signer: signer}, nil
+}
+
+func (c *L2Client) GetOrder(ctx context.Context, orderID string) (*types.OpenOrder, error) {
+	b, err := c.transport.DoJSON(ctx, http.MethodGet, c.endpoint("/data/order/"+url.PathEscape(orderID)), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp types.OpenOrder
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *L2Client) GetOpenOrders(ctx context.Context, req types.GetOpenOrdersRequest) ([]types.OpenOrder, error) {
+	q := url.Values{}
+	if req.Market != "" {
+		q.Set("market", req.Market)
+	}
+	if req.AssetID != "" {
+		q.Set("asset_id", req.AssetID)
+	}
+
+	u := c.endpoint("/data/orders")
+	if len(q) > 0 {
+		u = u + "?" + q.Encode()
+	}
+
+	b, err := c.transport.DoJSON(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp []types.OpenOrder
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PostOrder submits an already-built, EIP-712-signed order for matching.
+// Constructing/signing the order itself is a separate concern from L2 request
+// authentication and is left to the caller (or a future order-builder package).
+func (c *L2Client) PostOrder(ctx context.Context, order any) (*types.OrderResponse, error) {
+	body, err := json.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := c.transport.DoJSON(ctx, http.MethodPost, c.endpoint("/order"), nil, body)
+	if err != nil {
+		return nil, err
+	}
+	var resp types.OrderResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *L2Client) CancelOrder(ctx context.Context, orderID string) (*types.CancelResponse, error) {
+	return c.CancelOrders(ctx, []string{orderID})
 }
 
-func NewL2Client(baseURL string, creds auth.APICreds, opts ...PublicClientOption) (*L2Client, error) {
-	pc, err := NewPublicClient(baseURL, opts...)
+func (c *L2Client) CancelOrders(ctx context.Context, orderIDs []string) (*types.CancelResponse, error) {
+	body, err := json.Marshal(types.CancelOrdersRequest{OrderIDs: orderIDs})
 	if err != nil {
 		return nil, err
 	}
 
-	return &L2Client{
-		PublicClient: pc,
-		creds:        creds,
-	}, nil
+	b, err := c.transport.DoJSON(ctx, http.MethodDelete, c.endpoint("/orders"), nil, body)
+	if err != nil {
+		return nil, err
+	}
+	var resp types.CancelResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *L2Client) GetTradeHistory(ctx context.Context, req types.GetTradeHistoryRequest) ([]types.Trade, error) {
+	q := url.Values{}
+	if req.Market != "" {
+		q.Set("market", req.Market)
+	}
+	if req.AssetID != "" {
+		q.Set("asset_id", req.AssetID)
+	}
+	if req.NextCursor != "" {
+		q.Set("next_cursor", req.NextCursor)
+	}
+
+	u := c.endpoint("/data/trades")
+	if len(q) > 0 {
+		u = u + "?" + q.Encode()
+	}
+
+	b, err := c.transport.DoJSON(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp []types.Trade
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *L2Client) GetApiKeys(ctx context.Context) ([]types.ApiKeyEntry, error) {
+	b, err := c.transport.DoJSON(ctx, http.MethodGet, c.endpoint("/auth/api-keys"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp []types.ApiKeyEntry
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *L2Client) DeriveApiKey(ctx context.Context) (*auth.APICreds, error) {
+	b, err := c.transport.DoJSON(ctx, http.MethodGet, c.endpoint("/auth/derive-api-key"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp auth.APICreds
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *L2Client) CreateApiKey(ctx context.Context) (*auth.APICreds, error) {
+	b, err := c.transport.DoJSON(ctx, http.MethodPost, c.endpoint("/auth/api-key"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var resp auth.APICreds
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
@@ -0,0 +1,59 @@
+package clob
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/star-gazer111/poly-go-clob-client/auth"
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+type fakeL1Signer struct {
+	addr common.Address
+}
+
+func (f fakeL1Signer) Address() common.Address { return f.addr }
+
+func (f fakeL1Signer) SignTypedData(ctx context.Context, typedData any) ([]byte, error) {
+	return make([]byte, 65), nil
+}
+
+func TestL2Client_SignsRequestsWithPolyHeaders(t *testing.T) {
+	var gotHeaders http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	creds := auth.APICreds{
+		Key:        "api-key",
+		Secret:     base64.URLEncoding.EncodeToString([]byte("super-secret")),
+		Passphrase: "pass",
+	}
+	signer := fakeL1Signer{addr: common.HexToAddress("0x1111111111111111111111111111111111111111")}
+
+	c, err := NewL2Client(srv.URL, signer, creds)
+	if err != nil {
+		t.Fatalf("NewL2Client: %v", err)
+	}
+
+	if _, err := c.GetOpenOrders(context.Background(), types.GetOpenOrdersRequest{}); err != nil {
+		t.Fatalf("GetOpenOrders: %v", err)
+	}
+
+	if gotHeaders.Get("POLY_API_KEY") != "api-key" {
+		t.Errorf("expected POLY_API_KEY header, got %q", gotHeaders.Get("POLY_API_KEY"))
+	}
+	if gotHeaders.Get("POLY_SIGNATURE") == "" {
+		t.Error("expected a non-empty POLY_SIGNATURE header")
+	}
+	if gotHeaders.Get("POLY_ADDRESS") != signer.Address().Hex() {
+		t.Errorf("expected POLY_ADDRESS=%s, got %q", signer.Address().Hex(), gotHeaders.Get("POLY_ADDRESS"))
+	}
+}
@@ -0,0 +1,236 @@
+package clob
+
+import (
+	"context"
+
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+// noMoreMarketsCursor is the sentinel next_cursor value the CLOB API returns
+// once the final page of a markets listing has been served.
+const noMoreMarketsCursor = "LTE="
+
+// fetchPage is the shape shared by Markets/SimplifiedMarkets/SamplingMarkets:
+// given a cursor, return this page's items and the cursor for the next one.
+type fetchPage[T any] func(ctx context.Context, cursor string) ([]T, string, error)
+
+type page[T any] struct {
+	items []T
+	err   error
+}
+
+// pageIterator prefetches the next page (via fetchPage) into a buffered
+// channel of one while the caller drains the current page, so callers see
+// network latency overlap with iteration rather than stacking up serially.
+type pageIterator[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	fetch  fetchPage[T]
+	pages  chan page[T]
+
+	current []T
+	idx     int
+	cur     T
+	err     error
+}
+
+func newPageIterator[T any](ctx context.Context, fetch fetchPage[T]) *pageIterator[T] {
+	ctx, cancel := context.WithCancel(ctx)
+	it := &pageIterator[T]{ctx: ctx, cancel: cancel, fetch: fetch, pages: make(chan page[T], 1)}
+	go it.produce(ctx)
+	return it
+}
+
+func (it *pageIterator[T]) produce(ctx context.Context) {
+	defer close(it.pages)
+	cursor := ""
+	for {
+		items, next, err := it.fetch(ctx, cursor)
+		select {
+		case it.pages <- page[T]{items: items, err: err}:
+		case <-ctx.Done():
+			return
+		}
+		if err != nil || next == "" || next == noMoreMarketsCursor {
+			return
+		}
+		cursor = next
+	}
+}
+
+// Next advances to the next item, blocking on network I/O only when the
+// prefetched page is exhausted. It returns false at the end of the listing
+// or on error; callers must then check Err.
+func (it *pageIterator[T]) Next() bool {
+	for it.idx >= len(it.current) {
+		if it.err != nil {
+			return false
+		}
+		p, ok := <-it.pages
+		if !ok {
+			// The producer can exit on ctx cancellation while it's holding an
+			// in-flight page it never got to send (the select in produce
+			// races the channel send against ctx.Done()); surface that as
+			// ctx.Err() rather than leaving Err() nil after Next() => false.
+			if cerr := it.ctx.Err(); cerr != nil {
+				it.err = cerr
+			}
+			return false
+		}
+		if p.err != nil {
+			it.err = p.err
+			return false
+		}
+		it.current = p.items
+		it.idx = 0
+	}
+	it.cur = it.current[it.idx]
+	it.idx++
+	return true
+}
+
+// Value returns the item Next just advanced to.
+func (it *pageIterator[T]) Value() T { return it.cur }
+
+// Err returns the error that stopped iteration, if any. Context cancellation
+// surfaces here either as ctx.Err() itself or as whatever error fetchPage
+// returned for the in-flight request.
+func (it *pageIterator[T]) Err() error { return it.err }
+
+// Close stops the background prefetch goroutine. Safe to call multiple times
+// and safe to call before Next has returned false.
+func (it *pageIterator[T]) Close() { it.cancel() }
+
+// MarketIterator walks every page of PublicClient.Markets.
+type MarketIterator struct {
+	it *pageIterator[types.MarketResponse]
+}
+
+// IterMarkets returns a MarketIterator starting from the first page,
+// prefetching each next page while the caller processes the current one.
+// Iteration stops when the API's "no more pages" cursor is reached, ctx is
+// cancelled, or a request fails (see Err).
+func (c *PublicClient) IterMarkets(ctx context.Context) *MarketIterator {
+	return &MarketIterator{it: newPageIterator(ctx, func(ctx context.Context, cursor string) ([]types.MarketResponse, string, error) {
+		p, err := c.Markets(ctx, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return p.Data, p.NextCursor, nil
+	})}
+}
+
+// Next advances to the next market, returning false when iteration ends.
+func (m *MarketIterator) Next() bool { return m.it.Next() }
+
+// Value returns the market Next just advanced to.
+func (m *MarketIterator) Value() *types.MarketResponse { v := m.it.Value(); return &v }
+
+// Err returns the error that stopped iteration, if any.
+func (m *MarketIterator) Err() error { return m.it.Err() }
+
+// Close stops the iterator's background prefetch goroutine.
+func (m *MarketIterator) Close() { m.it.Close() }
+
+// AllMarkets walks every page of Markets, calling fn for each market in
+// order. Iteration stops at the first error returned by fn or encountered
+// while fetching a page.
+func (c *PublicClient) AllMarkets(ctx context.Context, fn func(*types.MarketResponse) error) error {
+	it := c.IterMarkets(ctx)
+	defer it.Close()
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// SimplifiedMarketIterator walks every page of PublicClient.SimplifiedMarkets.
+type SimplifiedMarketIterator struct {
+	it *pageIterator[types.SimplifiedMarketResponse]
+}
+
+// IterSimplifiedMarkets returns a SimplifiedMarketIterator starting from the
+// first page; see IterMarkets for prefetch/cancellation/error semantics.
+func (c *PublicClient) IterSimplifiedMarkets(ctx context.Context) *SimplifiedMarketIterator {
+	return &SimplifiedMarketIterator{it: newPageIterator(ctx, func(ctx context.Context, cursor string) ([]types.SimplifiedMarketResponse, string, error) {
+		p, err := c.SimplifiedMarkets(ctx, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return p.Data, p.NextCursor, nil
+	})}
+}
+
+// Next advances to the next simplified market, returning false when iteration ends.
+func (m *SimplifiedMarketIterator) Next() bool { return m.it.Next() }
+
+// Value returns the simplified market Next just advanced to.
+func (m *SimplifiedMarketIterator) Value() *types.SimplifiedMarketResponse {
+	v := m.it.Value()
+	return &v
+}
+
+// Err returns the error that stopped iteration, if any.
+func (m *SimplifiedMarketIterator) Err() error { return m.it.Err() }
+
+// Close stops the iterator's background prefetch goroutine.
+func (m *SimplifiedMarketIterator) Close() { m.it.Close() }
+
+// AllSimplifiedMarkets walks every page of SimplifiedMarkets, calling fn for
+// each simplified market in order. Iteration stops at the first error
+// returned by fn or encountered while fetching a page.
+func (c *PublicClient) AllSimplifiedMarkets(ctx context.Context, fn func(*types.SimplifiedMarketResponse) error) error {
+	it := c.IterSimplifiedMarkets(ctx)
+	defer it.Close()
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// SamplingMarketIterator walks every page of PublicClient.SamplingMarkets.
+type SamplingMarketIterator struct {
+	it *pageIterator[types.MarketResponse]
+}
+
+// IterSamplingMarkets returns a SamplingMarketIterator starting from the
+// first page; see IterMarkets for prefetch/cancellation/error semantics.
+func (c *PublicClient) IterSamplingMarkets(ctx context.Context) *SamplingMarketIterator {
+	return &SamplingMarketIterator{it: newPageIterator(ctx, func(ctx context.Context, cursor string) ([]types.MarketResponse, string, error) {
+		p, err := c.SamplingMarkets(ctx, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		return p.Data, p.NextCursor, nil
+	})}
+}
+
+// Next advances to the next sampling market, returning false when iteration ends.
+func (m *SamplingMarketIterator) Next() bool { return m.it.Next() }
+
+// Value returns the sampling market Next just advanced to.
+func (m *SamplingMarketIterator) Value() *types.MarketResponse { v := m.it.Value(); return &v }
+
+// Err returns the error that stopped iteration, if any.
+func (m *SamplingMarketIterator) Err() error { return m.it.Err() }
+
+// Close stops the iterator's background prefetch goroutine.
+func (m *SamplingMarketIterator) Close() { m.it.Close() }
+
+// AllSamplingMarkets walks every page of SamplingMarkets, calling fn for
+// each market in order. Iteration stops at the first error returned by fn
+// or encountered while fetching a page.
+func (c *PublicClient) AllSamplingMarkets(ctx context.Context, fn func(*types.MarketResponse) error) error {
+	it := c.IterSamplingMarkets(ctx)
+	defer it.Close()
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
@@ -0,0 +1,255 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+func marketsPageServer(t *testing.T, pages map[string]types.MarketsPage) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("next_cursor")
+		page, ok := pages[cursor]
+		if !ok {
+			t.Fatalf("unexpected cursor: %q", cursor)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestMarketIterator_WalksAllPagesAndStopsOnSentinel(t *testing.T) {
+	condA, condB, condC := "a", "b", "c"
+	pages := map[string]types.MarketsPage{
+		"": {
+			Data:       []types.MarketResponse{{ConditionID: &condA}, {ConditionID: &condB}},
+			NextCursor: "page2",
+		},
+		"page2": {
+			Data:       []types.MarketResponse{{ConditionID: &condC}},
+			NextCursor: noMoreMarketsCursor,
+		},
+	}
+	srv := marketsPageServer(t, pages)
+	defer srv.Close()
+
+	c, err := NewPublicClient(srv.URL)
+	if err != nil {
+		t.Fatalf("NewPublicClient: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	it := c.IterMarkets(ctx)
+	defer it.Close()
+
+	var got []string
+	for it.Next() {
+		got = append(got, *it.Value().ConditionID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected Err: %v", err)
+	}
+	if len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Fatalf("unexpected markets: %v", got)
+	}
+}
+
+func TestMarketIterator_PropagatesPageFetchError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c, err := NewPublicClient(srv.URL)
+	if err != nil {
+		t.Fatalf("NewPublicClient: %v", err)
+	}
+
+	it := c.IterMarkets(context.Background())
+	defer it.Close()
+
+	if it.Next() {
+		t.Fatal("expected Next to return false on fetch error")
+	}
+	if it.Err() == nil {
+		t.Fatal("expected a non-nil Err after a failed fetch")
+	}
+}
+
+func TestMarketIterator_StopsOnContextCancellation(t *testing.T) {
+	condA := "a"
+	pages := map[string]types.MarketsPage{
+		"": {Data: []types.MarketResponse{{ConditionID: &condA}}, NextCursor: "page2"},
+	}
+	srv := marketsPageServer(t, pages)
+	defer srv.Close()
+
+	c, err := NewPublicClient(srv.URL)
+	if err != nil {
+		t.Fatalf("NewPublicClient: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := c.IterMarkets(ctx)
+	defer it.Close()
+
+	if !it.Next() {
+		t.Fatalf("expected first item, got Err=%v", it.Err())
+	}
+	cancel()
+
+	if it.Next() {
+		t.Fatal("expected Next to return false once ctx is cancelled")
+	}
+	if it.Err() == nil {
+		t.Fatal("expected a non-nil Err after cancellation")
+	}
+}
+
+func TestAllMarkets_VisitsEveryItemInOrder(t *testing.T) {
+	condA, condB := "a", "b"
+	pages := map[string]types.MarketsPage{
+		"": {Data: []types.MarketResponse{{ConditionID: &condA}}, NextCursor: "page2"},
+		"page2": {
+			Data:       []types.MarketResponse{{ConditionID: &condB}},
+			NextCursor: noMoreMarketsCursor,
+		},
+	}
+	srv := marketsPageServer(t, pages)
+	defer srv.Close()
+
+	c, err := NewPublicClient(srv.URL)
+	if err != nil {
+		t.Fatalf("NewPublicClient: %v", err)
+	}
+
+	var got []string
+	err = c.AllMarkets(context.Background(), func(m *types.MarketResponse) error {
+		got = append(got, *m.ConditionID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("AllMarkets: %v", err)
+	}
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("unexpected markets: %v", got)
+	}
+}
+
+func TestAllMarkets_StopsAtCallbackError(t *testing.T) {
+	condA, condB := "a", "b"
+	pages := map[string]types.MarketsPage{
+		"": {Data: []types.MarketResponse{{ConditionID: &condA}}, NextCursor: "page2"},
+		"page2": {
+			Data:       []types.MarketResponse{{ConditionID: &condB}},
+			NextCursor: noMoreMarketsCursor,
+		},
+	}
+	srv := marketsPageServer(t, pages)
+	defer srv.Close()
+
+	c, err := NewPublicClient(srv.URL)
+	if err != nil {
+		t.Fatalf("NewPublicClient: %v", err)
+	}
+
+	wantErr := errors.New("stop here")
+	var visited int
+	err = c.AllMarkets(context.Background(), func(m *types.MarketResponse) error {
+		visited++
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected wantErr, got %v", err)
+	}
+	if visited != 1 {
+		t.Fatalf("expected callback to stop after first item, got %d calls", visited)
+	}
+}
+
+func simplifiedMarketsPageServer(t *testing.T, pages map[string]types.SimplifiedMarketsPage) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("next_cursor")
+		page, ok := pages[cursor]
+		if !ok {
+			t.Fatalf("unexpected cursor: %q", cursor)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestSimplifiedMarketIterator_WalksAllPages(t *testing.T) {
+	condA, condB := "a", "b"
+	pages := map[string]types.SimplifiedMarketsPage{
+		"": {
+			Data:       []types.SimplifiedMarketResponse{{ConditionID: &condA}},
+			NextCursor: "page2",
+		},
+		"page2": {
+			Data:       []types.SimplifiedMarketResponse{{ConditionID: &condB}},
+			NextCursor: noMoreMarketsCursor,
+		},
+	}
+	srv := simplifiedMarketsPageServer(t, pages)
+	defer srv.Close()
+
+	c, err := NewPublicClient(srv.URL)
+	if err != nil {
+		t.Fatalf("NewPublicClient: %v", err)
+	}
+
+	var got []string
+	it := c.IterSimplifiedMarkets(context.Background())
+	defer it.Close()
+	for it.Next() {
+		got = append(got, *it.Value().ConditionID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected Err: %v", err)
+	}
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("unexpected simplified markets: %v", got)
+	}
+}
+
+func TestSamplingMarketIterator_WalksAllPages(t *testing.T) {
+	condA, condB := "a", "b"
+	pages := map[string]types.MarketsPage{
+		"": {Data: []types.MarketResponse{{ConditionID: &condA}}, NextCursor: "page2"},
+		"page2": {
+			Data:       []types.MarketResponse{{ConditionID: &condB}},
+			NextCursor: noMoreMarketsCursor,
+		},
+	}
+	srv := marketsPageServer(t, pages)
+	defer srv.Close()
+
+	c, err := NewPublicClient(srv.URL)
+	if err != nil {
+		t.Fatalf("NewPublicClient: %v", err)
+	}
+
+	var got []string
+	it := c.IterSamplingMarkets(context.Background())
+	defer it.Close()
+	for it.Next() {
+		got = append(got, *it.Value().ConditionID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected Err: %v", err)
+	}
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("unexpected sampling markets: %v", got)
+	}
+}
@@ -8,7 +8,12 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/shopspring/decimal"
+	"github.com/star-gazer111/poly-go-clob-client/batcher"
+	"github.com/star-gazer111/poly-go-clob-client/cache"
+	"github.com/star-gazer111/poly-go-clob-client/internal/redaction"
 	"github.com/star-gazer111/poly-go-clob-client/internal/transport"
+	"github.com/star-gazer111/poly-go-clob-client/ratelimit"
 	"github.com/star-gazer111/poly-go-clob-client/types"
 )
 
@@ -16,8 +21,20 @@ type PublicClient struct {
 	baseURL      *url.URL
 	transport    *transport.Transport
 	requireHTTPS bool
+	marketWSURL  string
+
+	// *Batched method dedup groups, held for the client's lifetime so that
+	// concurrent calls sharing identical chunks coalesce into one request.
+	// pricesGroup is keyed flat as "tokenID|side" (see GetPricesBatched).
+	midpointsGroup *batcher.Group[map[string]decimal.Decimal]
+	pricesGroup    *batcher.Group[map[string]decimal.Decimal]
+	spreadsGroup   *batcher.Group[map[string]decimal.Decimal]
 }
 
+// DefaultMarketWSURL is Polymarket's public market-data WebSocket endpoint,
+// used by SubscribeBook unless overridden via WithMarketWSURL.
+const DefaultMarketWSURL = "wss://ws-subscriptions-clob.polymarket.com/ws/market"
+
 // PublicClientOption configures the PublicClient
 type PublicClientOption func(*PublicClient)
 
@@ -46,14 +63,95 @@ func WithRequireHTTPS(require bool) PublicClientOption {
 	}
 }
 
+// WithRateLimiter enables client-side, per-endpoint token-bucket throttling
+// (see the ratelimit package) on top of whatever transport is otherwise
+// configured. Applies to PublicClient and, since they build on it, L1Client
+// and L2Client as well.
+func WithRateLimiter(policy ratelimit.Policy) PublicClientOption {
+	return func(c *PublicClient) {
+		c.transport = c.transport.WithEndpointLimiter(ratelimit.NewLimiter(policy))
+	}
+}
+
+// WithFailoverEndpoints enables multi-endpoint failover/load-balancing at
+// the transport level (see transport.EndpointPicker): each attempt dials
+// whichever endpoint the picker selects per policy.Strategy (round-robin by
+// default, or weighted-random via Endpoint.Weight), and a backend that
+// errors, returns 5xx/429, or has its own circuit breaker open is demoted
+// with exponential cooldown so retries land on a different, healthy
+// endpoint instead of the same dead host. An empty endpoints list is a
+// no-op, leaving the client's single baseURL in place.
+func WithFailoverEndpoints(endpoints []transport.Endpoint, policy transport.EndpointPickerPolicy) PublicClientOption {
+	return func(c *PublicClient) {
+		if picker := transport.NewEndpointPicker(endpoints, policy); picker != nil {
+			c.transport = c.transport.WithEndpointPicker(picker)
+		}
+	}
+}
+
+// WithCache enables response caching of GET endpoints matching rules (e.g.
+// {Method: http.MethodGet, PathPattern: "/markets/*", TTL: 30 * time.Second}),
+// backed by cache. Cache hits skip the network entirely; only successful
+// (2xx) JSON responses are stored. See the cache package for the in-memory
+// cache.NewMemoryCache and the cache/rediscache subpackage for an example
+// of plugging in an external store.
+func WithCache(c cache.Cache, rules []cache.CacheRule) PublicClientOption {
+	return func(pc *PublicClient) {
+		pc.transport = pc.transport.WithCache(c, rules)
+	}
+}
+
+// WithRequestIDGenerator overrides how a request ID is minted for calls
+// whose ctx doesn't already carry one via transport.ContextWithRequestID.
+// Defaults to a built-in ULID-shaped generator.
+func WithRequestIDGenerator(gen func() string) PublicClientOption {
+	return func(c *PublicClient) {
+		c.transport = c.transport.WithRequestIDGenerator(gen)
+	}
+}
+
+// WithLogger enables request/response/retry logging: l is notified for
+// every call through this client, tagged with that call's request ID and
+// with secrets already redacted from headers, so operators can correlate
+// retries and failures across log lines. See transport.Logger.
+func WithLogger(l transport.Logger) PublicClientOption {
+	return func(c *PublicClient) {
+		c.transport = c.transport.WithLogger(l)
+	}
+}
+
+// WithRedactionPolicy overrides how WithLogger's Logger redacts headers,
+// letting integrators add vendor-specific sensitive header names/patterns
+// without forking the redaction package. p.RedactJSON is also available for
+// integrators who want the same field-level rules applied to response
+// bodies they log themselves. Defaults to redaction.DefaultPolicy().
+func WithRedactionPolicy(p *redaction.Policy) PublicClientOption {
+	return func(c *PublicClient) {
+		c.transport = c.transport.WithRedactionPolicy(p)
+	}
+}
+
+// WithMarketWSURL overrides the market-data WebSocket endpoint used by
+// SubscribeBook (default: DefaultMarketWSURL). Mainly useful for pointing
+// tests at a local echo server.
+func WithMarketWSURL(wsURL string) PublicClientOption {
+	return func(c *PublicClient) {
+		c.marketWSURL = wsURL
+	}
+}
+
 // NewPublicClient constructs a public-only client
 //
 // - baseURL must be a valid URL (non-empty)
 // - https is recommended we can optionally enforce via WithRequireHTTPS(true)
 func NewPublicClient(baseURL string, opts ...PublicClientOption) (*PublicClient, error) {
 	c := &PublicClient{
-		transport:    transport.NewTransport(http.DefaultClient, transport.DefaultPolicy()),
-		requireHTTPS: false,
+		transport:      transport.NewTransport(http.DefaultClient, transport.DefaultPolicy()),
+		requireHTTPS:   false,
+		marketWSURL:    DefaultMarketWSURL,
+		midpointsGroup: batcher.NewGroup[map[string]decimal.Decimal](),
+		pricesGroup:    batcher.NewGroup[map[string]decimal.Decimal](),
+		spreadsGroup:   batcher.NewGroup[map[string]decimal.Decimal](),
 	}
 
 	for _, opt := range opts {
@@ -143,7 +241,7 @@ func (c *PublicClient) Ping(ctx context.Context) (*PingResponse, error) {
 
 func (c *PublicClient) OrderBook(ctx context.Context, req *types.OrderBookSummaryRequest) (*types.OrderBookSummaryResponse, error) {
 	q := url.Values{}
-	q.Add("token_id", fmt.Sprintf("%d", req.TokenId))
+	q.Add("token_id", req.TokenId)
 
 	// Side is int, so we send as "0" (Buy) or "1" (Sell)
 
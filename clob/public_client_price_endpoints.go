@@ -0,0 +1,169 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/shopspring/decimal"
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+// Midpoint fetches the current midpoint price for a single token.
+func (c *PublicClient) Midpoint(ctx context.Context, req *types.MidpointRequest) (*types.MidpointResponse, error) {
+	u := c.endpoint("/midpoint") + "?" + url.Values{"token_id": {req.TokenId}}.Encode()
+
+	b, err := c.transport.DoJSON(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp types.MidpointResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Midpoints fetches midpoint prices for multiple tokens in a single request,
+// keyed by token ID.
+func (c *PublicClient) Midpoints(ctx context.Context, reqs []types.MidpointRequest) (map[string]decimal.Decimal, error) {
+	body, err := json.Marshal(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := c.transport.DoJSON(ctx, http.MethodPost, c.endpoint("/midpoints"), nil, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp map[string]decimal.Decimal
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetPrice fetches the current best price for a single (token, side).
+func (c *PublicClient) GetPrice(ctx context.Context, req types.PriceRequest) (*types.PriceResponse, error) {
+	u := c.endpoint("/price") + "?" + url.Values{"token_id": {req.TokenId}, "side": {req.Side}}.Encode()
+
+	b, err := c.transport.DoJSON(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp types.PriceResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetPrices fetches best prices for multiple (token, side) pairs in a single
+// request, keyed by token ID and then side.
+func (c *PublicClient) GetPrices(ctx context.Context, reqs []types.PriceRequest) (map[string]map[string]decimal.Decimal, error) {
+	body, err := json.Marshal(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := c.transport.DoJSON(ctx, http.MethodPost, c.endpoint("/prices"), nil, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp map[string]map[string]decimal.Decimal
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSpread fetches the current bid/ask spread for a single token.
+func (c *PublicClient) GetSpread(ctx context.Context, req types.SpreadRequest) (*types.SpreadResponse, error) {
+	q := url.Values{"token_id": {req.TokenId}}
+	if req.Side != nil {
+		q.Set("side", fmt.Sprintf("%d", *req.Side))
+	}
+	u := c.endpoint("/spread") + "?" + q.Encode()
+
+	b, err := c.transport.DoJSON(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp types.SpreadResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetSpreads fetches spreads for multiple tokens in a single request, keyed
+// by token ID.
+func (c *PublicClient) GetSpreads(ctx context.Context, reqs []types.SpreadRequest) (map[string]decimal.Decimal, error) {
+	body, err := json.Marshal(reqs)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := c.transport.DoJSON(ctx, http.MethodPost, c.endpoint("/spreads"), nil, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp map[string]decimal.Decimal
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetPricesHistory fetches historical price samples for a market, either
+// over a named Interval or an explicit StartTs/EndTs range (exactly one of
+// the two must be set).
+func (c *PublicClient) GetPricesHistory(ctx context.Context, req types.PricesHistoryRequest) (*types.PricesHistoryResponse, error) {
+	hasInterval := req.Interval != ""
+	hasRange := req.StartTs != nil || req.EndTs != nil
+
+	if !hasInterval && !hasRange {
+		return nil, types.ValidationErr("must provide either Interval or a StartTs/EndTs range")
+	}
+	if hasInterval && hasRange {
+		return nil, types.ValidationErr("cannot provide both Interval and a StartTs/EndTs range")
+	}
+	if hasRange && (req.StartTs == nil || req.EndTs == nil) {
+		return nil, types.ValidationErr("a StartTs/EndTs range requires both bounds")
+	}
+
+	q := url.Values{"market": {req.Market}}
+	if hasInterval {
+		q.Set("interval", string(req.Interval))
+	}
+	if req.StartTs != nil {
+		q.Set("startTs", fmt.Sprintf("%d", *req.StartTs))
+	}
+	if req.EndTs != nil {
+		q.Set("endTs", fmt.Sprintf("%d", *req.EndTs))
+	}
+	if req.Fidelity != nil {
+		q.Set("fidelity", fmt.Sprintf("%d", *req.Fidelity))
+	}
+
+	u := c.endpoint("/prices-history") + "?" + q.Encode()
+
+	b, err := c.transport.DoJSON(ctx, http.MethodGet, u, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp types.PricesHistoryResponse
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
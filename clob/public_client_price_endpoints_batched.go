@@ -0,0 +1,93 @@
+package clob
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/star-gazer111/poly-go-clob-client/batcher"
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+// MidpointsBatched is Midpoints for large token lists: it splits reqs into
+// chunks of at most opts.ChunkSize, fetches chunks concurrently (bounded by
+// opts.Workers), coalesces identical concurrent chunk requests, and merges
+// the results. A *batcher.MultiError is returned (alongside whatever
+// succeeded) if any chunk failed. Pass batcher.Options{} to use
+// batcher.DefaultOptions().
+func (c *PublicClient) MidpointsBatched(ctx context.Context, reqs []types.MidpointRequest, opts batcher.Options) (map[string]decimal.Decimal, error) {
+	return batcher.Run(ctx, reqs, opts, c.midpointsGroup, midpointChunkKey,
+		func(ctx context.Context, chunk []types.MidpointRequest) (map[string]decimal.Decimal, error) {
+			return c.Midpoints(ctx, chunk)
+		})
+}
+
+func midpointChunkKey(chunk []types.MidpointRequest) string {
+	ids := make([]string, len(chunk))
+	for i, r := range chunk {
+		ids[i] = r.TokenId
+	}
+	return strings.Join(ids, ",")
+}
+
+// GetPricesBatched is GetPrices for large token lists, with the same
+// chunking/dedup/partial-failure semantics as MidpointsBatched.
+func (c *PublicClient) GetPricesBatched(ctx context.Context, reqs []types.PriceRequest, opts batcher.Options) (map[string]map[string]decimal.Decimal, error) {
+	flat, err := batcher.Run(ctx, reqs, opts, c.pricesGroup, priceChunkKey,
+		func(ctx context.Context, chunk []types.PriceRequest) (map[string]decimal.Decimal, error) {
+			nested, err := c.GetPrices(ctx, chunk)
+			if err != nil {
+				return nil, err
+			}
+			return flattenPrices(nested), nil
+		})
+
+	return unflattenPrices(flat), err
+}
+
+func priceChunkKey(chunk []types.PriceRequest) string {
+	keys := make([]string, len(chunk))
+	for i, r := range chunk {
+		keys[i] = r.TokenId + "|" + r.Side
+	}
+	return strings.Join(keys, ",")
+}
+
+func flattenPrices(nested map[string]map[string]decimal.Decimal) map[string]decimal.Decimal {
+	flat := make(map[string]decimal.Decimal, len(nested))
+	for tokenID, bySide := range nested {
+		for side, price := range bySide {
+			flat[tokenID+"|"+side] = price
+		}
+	}
+	return flat
+}
+
+func unflattenPrices(flat map[string]decimal.Decimal) map[string]map[string]decimal.Decimal {
+	nested := make(map[string]map[string]decimal.Decimal, len(flat))
+	for key, price := range flat {
+		tokenID, side, _ := strings.Cut(key, "|")
+		if nested[tokenID] == nil {
+			nested[tokenID] = make(map[string]decimal.Decimal)
+		}
+		nested[tokenID][side] = price
+	}
+	return nested
+}
+
+// GetSpreadsBatched is GetSpreads for large token lists, with the same
+// chunking/dedup/partial-failure semantics as MidpointsBatched.
+func (c *PublicClient) GetSpreadsBatched(ctx context.Context, reqs []types.SpreadRequest, opts batcher.Options) (map[string]decimal.Decimal, error) {
+	return batcher.Run(ctx, reqs, opts, c.spreadsGroup, spreadChunkKey,
+		func(ctx context.Context, chunk []types.SpreadRequest) (map[string]decimal.Decimal, error) {
+			return c.GetSpreads(ctx, chunk)
+		})
+}
+
+func spreadChunkKey(chunk []types.SpreadRequest) string {
+	ids := make([]string, len(chunk))
+	for i, r := range chunk {
+		ids[i] = r.TokenId
+	}
+	return strings.Join(ids, ",")
+}
@@ -0,0 +1,139 @@
+package clob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/star-gazer111/poly-go-clob-client/batcher"
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+func TestMidpointsBatched_MergesAcrossChunks(t *testing.T) {
+	const total = 1000
+	srv, c := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var reqs []types.MidpointRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		resp := make(map[string]decimal.Decimal, len(reqs))
+		for _, req := range reqs {
+			n, _ := strconv.Atoi(req.TokenId)
+			resp[req.TokenId] = decimal.NewFromInt(int64(n))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+	defer srv.Close()
+
+	reqs := make([]types.MidpointRequest, total)
+	for i := range reqs {
+		reqs[i] = types.MidpointRequest{TokenId: strconv.Itoa(i)}
+	}
+
+	merged, err := c.MidpointsBatched(context.Background(), reqs, batcher.Options{ChunkSize: 97, Workers: 8})
+	if err != nil {
+		t.Fatalf("MidpointsBatched err: %v", err)
+	}
+	if len(merged) != total {
+		t.Fatalf("expected %d entries, got %d", total, len(merged))
+	}
+	for i := 0; i < total; i++ {
+		id := strconv.Itoa(i)
+		if !merged[id].Equal(decimal.NewFromInt(int64(i))) {
+			t.Fatalf("entry %s: got %v", id, merged[id])
+		}
+	}
+}
+
+func TestGetPricesBatched_UnflattensToNestedShape(t *testing.T) {
+	srv, c := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var reqs []types.PriceRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		resp := make(map[string]map[string]decimal.Decimal)
+		for _, req := range reqs {
+			if resp[req.TokenId] == nil {
+				resp[req.TokenId] = make(map[string]decimal.Decimal)
+			}
+			resp[req.TokenId][req.Side] = decimal.NewFromFloat(0.5)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+	defer srv.Close()
+
+	reqs := []types.PriceRequest{
+		{TokenId: "1", Side: "BUY"},
+		{TokenId: "1", Side: "SELL"},
+		{TokenId: "2", Side: "BUY"},
+	}
+
+	merged, err := c.GetPricesBatched(context.Background(), reqs, batcher.Options{})
+	if err != nil {
+		t.Fatalf("GetPricesBatched err: %v", err)
+	}
+	if len(merged) != 2 {
+		t.Fatalf("expected 2 tokens, got %d", len(merged))
+	}
+	if len(merged["1"]) != 2 {
+		t.Fatalf("expected 2 sides for token 1, got %d", len(merged["1"]))
+	}
+	if !merged["1"]["BUY"].Equal(decimal.NewFromFloat(0.5)) {
+		t.Fatalf("unexpected price: %v", merged["1"]["BUY"])
+	}
+}
+
+func TestGetSpreadsBatched_DedupesConcurrentIdenticalLookups(t *testing.T) {
+	var calls int32
+	srv, c := setupTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		var reqs []types.SpreadRequest
+		if err := json.NewDecoder(r.Body).Decode(&reqs); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		resp := make(map[string]decimal.Decimal, len(reqs))
+		for _, req := range reqs {
+			resp[req.TokenId] = decimal.NewFromFloat(0.1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+	defer srv.Close()
+
+	reqs := []types.SpreadRequest{{TokenId: "a"}, {TokenId: "b"}, {TokenId: "c"}}
+
+	const n = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			merged, err := c.GetSpreadsBatched(context.Background(), reqs, batcher.Options{})
+			if err != nil {
+				errs <- err
+				return
+			}
+			if len(merged) != 3 {
+				errs <- fmt.Errorf("expected 3 entries, got %d", len(merged))
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected exactly 1 HTTP call, got %d", got)
+	}
+}
@@ -0,0 +1,53 @@
+package clob
+
+import (
+	"context"
+
+	"github.com/star-gazer111/poly-go-clob-client/book"
+	"github.com/star-gazer111/poly-go-clob-client/stream"
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+// SubscribeBook opens a market-channel WebSocket connection, subscribes to
+// tokenID, and returns a book.LocalBook that's kept in sync for as long as
+// the returned book isn't closed. Callers get a maintained order book
+// without writing any stream plumbing of their own.
+//
+// If an applied price_change can't be trusted (it arrived before any
+// snapshot, or for a different market than the current ladder), the ladder
+// is desynced and SubscribeBook automatically resyncs it with a REST
+// OrderBook call. See book.LocalBook.Desynced for why this isn't a
+// comparison against Polymarket's server-reported book hash.
+func (c *PublicClient) SubscribeBook(ctx context.Context, tokenID string) (*book.LocalBook, error) {
+	lb := book.NewLocalBook(tokenID)
+
+	sc := stream.NewClient(c.marketWSURL, "")
+	sc.OnBookUpdate(func(m stream.BookMessage) {
+		if m.AssetID != tokenID {
+			return
+		}
+		lb.ApplyBookUpdate(m)
+	})
+	sc.OnPriceChange(func(m stream.PriceChangeMessage) {
+		if m.AssetID != tokenID {
+			return
+		}
+		if lb.ApplyPriceChange(m) {
+			return
+		}
+		if resp, err := c.OrderBook(ctx, &types.OrderBookSummaryRequest{TokenId: tokenID}); err == nil {
+			lb.ResetFromSummary(resp)
+		}
+	})
+
+	if err := sc.Connect(ctx); err != nil {
+		return nil, err
+	}
+	if err := sc.Subscribe(tokenID); err != nil {
+		_ = sc.Close()
+		return nil, err
+	}
+
+	lb.SetCloseFunc(sc.Close)
+	return lb, nil
+}
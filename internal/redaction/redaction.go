@@ -1,7 +1,9 @@
 package redaction
 
 import (
+	"encoding/json"
 	"net/http"
+	"regexp"
 	"strings"
 )
 
@@ -12,14 +14,15 @@ const DefaultRedaction = "***"
 // It preserves a small prefix/suffix to help debugging without leaking secrets
 //
 // Some simple examples to understand:
-//   "abcd" -> "***"
-//   "abcdefg" -> "abc***efg"
+//
+//	"abcd" -> "***"
+//	"abcdefghi" -> "abc***ghi"
 func Redact(s string) string {
 	s = strings.TrimSpace(s)
 	if s == "" {
 		return ""
 	}
-	// Keeping the behavior conservative for short secrets 
+	// Keeping the behavior conservative for short secrets
 	if len(s) <= 8 {
 		return DefaultRedaction
 	}
@@ -27,10 +30,93 @@ func Redact(s string) string {
 	return s[:3] + DefaultRedaction + s[len(s)-3:]
 }
 
-// RedactHeaders returns a copy of headers with sensitive values redacted & it never mutates the input header map
+// jwtPattern, hexPrivateKeyPattern and hexSignaturePattern back
+// DefaultPolicy's ValuePatterns: shapes that identify a value as sensitive
+// even in a header or JSON field our name-based rules don't already know
+// about.
+var (
+	jwtPattern           = regexp.MustCompile(`eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)
+	hexPrivateKeyPattern = regexp.MustCompile(`0x[0-9a-fA-F]{64}`)
+	hexSignaturePattern  = regexp.MustCompile(`\b[0-9a-fA-F]{64}\b`)
+)
+
+// FieldRule configures how a JSON field matched by Policy.FieldPolicies gets
+// redacted.
+type FieldRule struct {
+	// Partial redacts with Redact's prefix/suffix-preserving form instead of
+	// fully replacing the value with DefaultRedaction.
+	Partial bool
+}
+
+// Policy controls which header names, header values, and JSON body fields
+// RedactHeaders/RedactJSON treat as sensitive. DefaultPolicy holds this
+// package's built-in rules; integrators piping logs into a shared
+// pipeline can start from it and add vendor-specific names/patterns/fields
+// (e.g. extra Polymarket L2 headers) via WithRedactionPolicy instead of
+// forking the package.
+type Policy struct {
+	// SensitiveHeaderNames are canonical header names (as returned by
+	// http.CanonicalHeaderKey) that are always fully redacted regardless of
+	// value.
+	SensitiveHeaderNames []string
+	// SensitiveSubstrings marks any header whose lowercased name contains
+	// one of these as sensitive, same treatment as SensitiveHeaderNames.
+	SensitiveSubstrings []string
+	// ValuePatterns are checked against header values not already caught by
+	// name; a match is fully redacted regardless of length, catching shapes
+	// like JWTs or 0x-prefixed private keys even in unrecognized headers.
+	ValuePatterns []*regexp.Regexp
+	// FieldPolicies redacts JSON body fields by dotted path (e.g.
+	// "credentials.api_secret") or bare key name (e.g. "passphrase"), the
+	// latter matching at any depth. See RedactJSON.
+	FieldPolicies map[string]FieldRule
+}
+
+// DefaultPolicy returns the package's built-in Policy: the same header
+// names/heuristics RedactHeaders always applied, plus patterns for common
+// on-chain secret shapes and FieldPolicies for the CLOB API's own
+// credential fields.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		SensitiveHeaderNames: []string{
+			"Authorization",
+			"Proxy-Authorization",
+			"Cookie",
+			"Set-Cookie",
+			"X-Api-Key",
+			"Api-Key",
+			"X-Api-Token",
+			"X-Auth-Token",
+			"X-Access-Token",
+			// Polymarket L1/L2 auth headers (see auth.L1AuthHeaders,
+			// auth.ApplyL2Headers). These are set with underscores
+			// (POLY_API_KEY, not Poly-Api-Key), so http.CanonicalHeaderKey
+			// only uppercases the first letter - Poly_api_key, not
+			// Poly-Api-Key.
+			"Poly_api_key",
+			"Poly_signature",
+			"Poly_passphrase",
+			"Poly_address",
+		},
+		SensitiveSubstrings: []string{"secret", "token", "pass", "key", "session"},
+		ValuePatterns: []*regexp.Regexp{
+			jwtPattern,
+			hexPrivateKeyPattern,
+			hexSignaturePattern,
+		},
+		FieldPolicies: map[string]FieldRule{
+			"api_secret": {},
+			"passphrase": {},
+			"signature":  {},
+		},
+	}
+}
+
+// RedactHeaders returns a copy of headers with sensitive values redacted
+// according to p & it never mutates the input header map.
 //
-// It redacts by header name and also by token patterns for unknown keys
-func RedactHeaders(h http.Header) http.Header {
+// It redacts by header name and also by token patterns for unknown keys.
+func (p *Policy) RedactHeaders(h http.Header) http.Header {
 	if h == nil {
 		return nil
 	}
@@ -42,8 +128,18 @@ func RedactHeaders(h http.Header) http.Header {
 		// always copy the slice to avoid aliasing
 		copied := make([]string, 0, len(vv))
 
+		// X-Request-Id is a correlation token, not a secret - whitelist it
+		// so operators can still grep logs for it even though it's long
+		// enough to otherwise trip the heuristic below. This is a transport
+		// invariant, not something a Policy should be able to turn off.
+		if isWhitelistedHeaderName(ck) {
+			copied = append(copied, vv...)
+			out[ck] = copied
+			continue
+		}
+
 		// redact based on known sensitive header names
-		if isSensitiveHeaderName(ck) {
+		if p.isSensitiveHeaderName(ck) {
 			for range vv {
 				copied = append(copied, DefaultRedaction)
 			}
@@ -53,7 +149,7 @@ func RedactHeaders(h http.Header) http.Header {
 
 		// for other headers redact if they look like secrets (e.g., Bearer tokens)
 		for _, v := range vv {
-			copied = append(copied, redactHeaderValueHeuristic(v))
+			copied = append(copied, p.redactHeaderValueHeuristic(v))
 		}
 		out[ck] = copied
 	}
@@ -61,41 +157,111 @@ func RedactHeaders(h http.Header) http.Header {
 	return out
 }
 
-// isSensitiveHeaderName returns true for headers that commonly carry secrets
-func isSensitiveHeaderName(canonicalKey string) bool {
+// RedactJSON returns a copy of b with any field matched by p.FieldPolicies
+// replaced per its FieldRule. b is expected to be a JSON object or array;
+// anything else (including malformed JSON) is returned unchanged, since
+// there's no structure to redact within. Key order is not preserved, since
+// redaction happens via a decode/re-encode round trip through a generic
+// map[string]interface{}.
+func (p *Policy) RedactJSON(b []byte) []byte {
+	if len(b) == 0 || len(p.FieldPolicies) == 0 {
+		return b
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return b
+	}
+
+	out, err := json.Marshal(p.redactValue("", v))
+	if err != nil {
+		return b
+	}
+	return out
+}
+
+func (p *Policy) redactValue(path string, v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, vv := range val {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			if rule, ok := p.fieldRule(childPath, k); ok {
+				out[k] = p.redactFieldValue(rule, vv)
+				continue
+			}
+			out[k] = p.redactValue(childPath, vv)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, vv := range val {
+			out[i] = p.redactValue(path, vv)
+		}
+		return out
+	default:
+		return val
+	}
+}
+
+// fieldRule looks up childPath (the full dotted path from the document
+// root) first, falling back to the bare key name so a rule like
+// FieldPolicies["passphrase"] matches that field at any nesting depth
+// without the caller needing to spell out every parent path.
+func (p *Policy) fieldRule(childPath, key string) (FieldRule, bool) {
+	if rule, ok := p.FieldPolicies[childPath]; ok {
+		return rule, true
+	}
+	rule, ok := p.FieldPolicies[key]
+	return rule, ok
+}
+
+func (p *Policy) redactFieldValue(rule FieldRule, v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return DefaultRedaction
+	}
+	if rule.Partial {
+		return Redact(s)
+	}
+	return DefaultRedaction
+}
+
+// isWhitelistedHeaderName returns true for headers that are never redacted
+// regardless of Policy, because they carry no secret even though they may
+// be long-lived/unique identifiers.
+func isWhitelistedHeaderName(canonicalKey string) bool {
 	switch canonicalKey {
-	case "Authorization",
-		"Proxy-Authorization",
-		"Cookie",
-		"Set-Cookie",
-		"X-Api-Key",
-		"Api-Key",
-		"X-Api-Token",
-		"X-Auth-Token",
-		"X-Access-Token",
-		// Polymarket specific-ish (can expand later)
-		"Poly-Api-Key",
-		"Poly-Api-Secret",
-		"Poly-Api-Passphrase":
+	case "X-Request-Id":
 		return true
 	default:
-		// Heuristic: any header name containing these substrings is treated as sensitive
-		lk := strings.ToLower(canonicalKey)
-		if strings.Contains(lk, "secret") ||
-			strings.Contains(lk, "token") ||
-			strings.Contains(lk, "pass") ||
-			strings.Contains(lk, "key") ||
-			strings.Contains(lk, "session") {
+		return false
+	}
+}
+
+func (p *Policy) isSensitiveHeaderName(canonicalKey string) bool {
+	for _, n := range p.SensitiveHeaderNames {
+		if http.CanonicalHeaderKey(n) == canonicalKey {
 			return true
 		}
-		return false
 	}
+	lk := strings.ToLower(canonicalKey)
+	for _, sub := range p.SensitiveSubstrings {
+		if strings.Contains(lk, strings.ToLower(sub)) {
+			return true
+		}
+	}
+	return false
 }
 
 // redactHeaderValueHeuristic tries to avoid leaking secrets for unknown keys
 // - "Bearer <token>" -> "Bearer ***"
+// - a value matching one of p.ValuePatterns -> fully redacted
 // - "<very long string>" -> prefix/suffix redacted
-func redactHeaderValueHeuristic(v string) string {
+func (p *Policy) redactHeaderValueHeuristic(v string) string {
 	v = strings.TrimSpace(v)
 	if v == "" {
 		return ""
@@ -105,6 +271,12 @@ func redactHeaderValueHeuristic(v string) string {
 		return "Bearer " + DefaultRedaction
 	}
 
+	for _, re := range p.ValuePatterns {
+		if re.MatchString(v) {
+			return DefaultRedaction
+		}
+	}
+
 	// if it looks long/secretish, redact with partial reveal
 	if len(v) >= 16 {
 		return Redact(v)
@@ -112,3 +284,13 @@ func redactHeaderValueHeuristic(v string) string {
 
 	return v
 }
+
+// defaultPolicy backs the package-level RedactHeaders below, kept for
+// existing callers that don't need a custom Policy.
+var defaultPolicy = DefaultPolicy()
+
+// RedactHeaders redacts h using DefaultPolicy. See Policy.RedactHeaders for
+// callers that need custom names/patterns/fields.
+func RedactHeaders(h http.Header) http.Header {
+	return defaultPolicy.RedactHeaders(h)
+}
@@ -0,0 +1,269 @@
+package transport
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+// BreakerPolicy configures the per-endpoint circuit breaker. A zero-value
+// BreakerPolicy (FailureThreshold <= 0) disables the breaker entirely.
+type BreakerPolicy struct {
+	// FailureThreshold is the number of qualifying failures within Window
+	// that trips the breaker open.
+	FailureThreshold int
+	// Window is the rolling period over which failures are counted; the
+	// counter resets once this elapses without a trip.
+	Window time.Duration
+	// Cooldown is how long the breaker stays open before admitting a single
+	// half-open probe request. Each time a probe fails (or the breaker trips
+	// again without an intervening success), the next cooldown doubles, up
+	// to MaxCooldown.
+	Cooldown time.Duration
+	// MaxCooldown caps the exponential backoff of Cooldown. Zero means no
+	// cap: the cooldown keeps doubling on every consecutive trip.
+	MaxCooldown time.Duration
+}
+
+func DefaultBreakerPolicy() BreakerPolicy {
+	return BreakerPolicy{
+		FailureThreshold: 5,
+		Window:           30 * time.Second,
+		Cooldown:         10 * time.Second,
+		MaxCooldown:      2 * time.Minute,
+	}
+}
+
+// State is a circuit breaker state.
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+type breakerEntry struct {
+	mu    sync.Mutex
+	state State
+
+	failures      int
+	windowStart   time.Time
+	openUntil     time.Time
+	probeInFlight bool
+
+	// consecutiveOpens counts trips since the last successful close, driving
+	// the exponential cooldown: a breaker that keeps failing its probes
+	// backs off instead of hammering a still-wounded upstream every Cooldown.
+	consecutiveOpens int
+
+	lastStatus *types.Status
+}
+
+// breakerRegistry tracks one breakerEntry per host+method+path-prefix key,
+// so a wounded endpoint doesn't take down calls to unrelated ones.
+type breakerRegistry struct {
+	mu       sync.Mutex
+	entries  map[string]*breakerEntry
+	policy   BreakerPolicy
+	onChange func(key string, from, to State)
+}
+
+func newBreakerRegistry(p BreakerPolicy, onChange func(key string, from, to State)) *breakerRegistry {
+	return &breakerRegistry{entries: make(map[string]*breakerEntry), policy: p, onChange: onChange}
+}
+
+func (r *breakerRegistry) entry(key string) *breakerEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.entries[key]
+	if !ok {
+		e = &breakerEntry{state: StateClosed}
+		r.entries[key] = e
+	}
+	return e
+}
+
+// allow reports whether a call for key may proceed. When the breaker is open
+// but Cooldown has elapsed, it transitions to half-open and admits exactly
+// one probe; further callers are refused until that probe resolves.
+func (r *breakerRegistry) allow(key string) bool {
+	if r.policy.FailureThreshold <= 0 {
+		return true
+	}
+
+	e := r.entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch e.state {
+	case StateOpen:
+		if time.Now().Before(e.openUntil) {
+			return false
+		}
+		if e.probeInFlight {
+			return false
+		}
+		e.probeInFlight = true
+		r.transition(key, e, StateHalfOpen)
+		return true
+	case StateHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+func (r *breakerRegistry) recordSuccess(key string) {
+	e := r.entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.failures = 0
+	e.probeInFlight = false
+	e.consecutiveOpens = 0
+	if e.state != StateClosed {
+		r.transition(key, e, StateClosed)
+	}
+}
+
+// resolveProbe clears an in-flight half-open probe for a status that isn't
+// itself evidence of upstream trouble (i.e. not the 5xx/429 class handled by
+// recordFailure) - a client/business error (400/401/404/...) still means the
+// server answered, so the probe succeeded from the breaker's perspective. A
+// status reaching allow()'s StateHalfOpen branch that resolves via neither
+// recordSuccess (2xx) nor recordFailure (5xx/429) would otherwise leave
+// probeInFlight set forever, permanently wedging that key in StateHalfOpen.
+// No-op outside StateHalfOpen.
+func (r *breakerRegistry) resolveProbe(key string) {
+	if r.policy.FailureThreshold <= 0 {
+		return
+	}
+
+	e := r.entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state != StateHalfOpen {
+		return
+	}
+	e.failures = 0
+	e.probeInFlight = false
+	e.consecutiveOpens = 0
+	r.transition(key, e, StateClosed)
+}
+
+func (r *breakerRegistry) recordFailure(key string, status *types.Status) {
+	if r.policy.FailureThreshold <= 0 {
+		return
+	}
+
+	e := r.entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if status != nil {
+		e.lastStatus = status
+	}
+
+	if e.state == StateHalfOpen {
+		// The probe itself failed: reopen immediately without waiting for
+		// the threshold, the upstream is still wounded.
+		e.probeInFlight = false
+		e.consecutiveOpens++
+		e.openUntil = time.Now().Add(r.cooldownFor(e.consecutiveOpens))
+		r.transition(key, e, StateOpen)
+		return
+	}
+
+	now := time.Now()
+	if e.windowStart.IsZero() || now.Sub(e.windowStart) > r.policy.Window {
+		e.windowStart = now
+		e.failures = 0
+	}
+	e.failures++
+
+	if e.failures >= r.policy.FailureThreshold {
+		e.consecutiveOpens++
+		e.openUntil = now.Add(r.cooldownFor(e.consecutiveOpens))
+		r.transition(key, e, StateOpen)
+	}
+}
+
+// cooldownFor returns the cooldown for the nth (1-indexed) consecutive trip:
+// Cooldown, 2x, 4x, ... capped at MaxCooldown (if set).
+func (r *breakerRegistry) cooldownFor(consecutiveOpens int) time.Duration {
+	d := r.policy.Cooldown
+	for i := 1; i < consecutiveOpens; i++ {
+		d *= 2
+		if r.policy.MaxCooldown > 0 && d >= r.policy.MaxCooldown {
+			return r.policy.MaxCooldown
+		}
+	}
+	if r.policy.MaxCooldown > 0 && d > r.policy.MaxCooldown {
+		d = r.policy.MaxCooldown
+	}
+	return d
+}
+
+// cooldownRemaining returns how much longer key's breaker stays open, or 0
+// if it isn't currently open.
+func (r *breakerRegistry) cooldownRemaining(key string) time.Duration {
+	e := r.entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.state == StateOpen {
+		if d := time.Until(e.openUntil); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func (r *breakerRegistry) lastStatusFor(key string) *types.Status {
+	e := r.entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.lastStatus
+}
+
+// transition must be called with e.mu held.
+func (r *breakerRegistry) transition(key string, e *breakerEntry, to State) {
+	from := e.state
+	e.state = to
+	if from == to {
+		return
+	}
+	if r.onChange != nil {
+		r.onChange(key, from, to)
+	}
+}
+
+// breakerKey groups requests by host + method + first path segment, so e.g.
+// GET /markets/123 and GET /markets/456 share one breaker while POST /orders
+// trips independently.
+func breakerKey(req *http.Request) string {
+	return req.Method + " " + req.URL.Host + pathPrefix(req.URL.Path)
+}
+
+func pathPrefix(path string) string {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return "/"
+	}
+	return "/" + strings.SplitN(trimmed, "/", 2)[0]
+}
@@ -0,0 +1,232 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+func TestBreakerOpensAfterConsecutive5xx(t *testing.T) {
+	var n int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n, 1)
+		w.WriteHeader(500)
+		_, _ = w.Write([]byte("boom"))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.Breaker = BreakerPolicy{FailureThreshold: 3, Window: time.Minute, Cooldown: time.Minute}
+
+	tr := NewTransport(http.DefaultClient, p)
+
+	for i := 0; i < 3; i++ {
+		if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil); err == nil {
+			t.Fatalf("attempt %d: expected 500 error", i)
+		}
+	}
+	if got := atomic.LoadInt32(&n); got != 3 {
+		t.Fatalf("expected 3 requests to hit the server, got %d", got)
+	}
+
+	// The 4th call should be short-circuited by the now-open breaker.
+	_, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil)
+	if err == nil {
+		t.Fatalf("expected circuit-open error")
+	}
+	var co *types.CircuitOpen
+	if !errors.As(err, &co) {
+		t.Fatalf("expected *types.CircuitOpen, got %T: %v", err, err)
+	}
+	if got := atomic.LoadInt32(&n); got != 3 {
+		t.Fatalf("expected breaker to short-circuit without hitting the server, server saw %d requests", got)
+	}
+}
+
+func TestBreakerAdmitsSingleProbeAfterCooldown(t *testing.T) {
+	var n int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&n, 1)
+		if count <= 2 {
+			w.WriteHeader(500)
+			_, _ = w.Write([]byte("boom"))
+			return
+		}
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.Breaker = BreakerPolicy{FailureThreshold: 2, Window: time.Minute, Cooldown: 40 * time.Millisecond}
+
+	tr := NewTransport(http.DefaultClient, p)
+
+	for i := 0; i < 2; i++ {
+		if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil); err == nil {
+			t.Fatalf("attempt %d: expected 500 error", i)
+		}
+	}
+
+	// Immediately after tripping, calls are short-circuited.
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil); err == nil {
+		t.Fatalf("expected circuit-open error before cooldown elapses")
+	}
+	if got := atomic.LoadInt32(&n); got != 2 {
+		t.Fatalf("expected still only 2 requests to hit the server, got %d", got)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	// Cooldown has elapsed: a single probe is admitted and should succeed.
+	out, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil)
+	if err != nil {
+		t.Fatalf("expected probe to succeed, got err: %v", err)
+	}
+	if string(out) != "ok" {
+		t.Fatalf("expected ok, got %q", string(out))
+	}
+	if got := atomic.LoadInt32(&n); got != 3 {
+		t.Fatalf("expected exactly 3 requests to hit the server, got %d", got)
+	}
+}
+
+func TestBreakerOpensOn429(t *testing.T) {
+	var n int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n, 1)
+		w.WriteHeader(429)
+		_, _ = w.Write([]byte("slow down"))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.Breaker = BreakerPolicy{FailureThreshold: 2, Window: time.Minute, Cooldown: time.Minute}
+
+	tr := NewTransport(http.DefaultClient, p)
+
+	for i := 0; i < 2; i++ {
+		if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil); err == nil {
+			t.Fatalf("attempt %d: expected 429 error", i)
+		}
+	}
+
+	_, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil)
+	if err == nil {
+		t.Fatalf("expected circuit-open error")
+	}
+	var co *types.CircuitOpen
+	if !errors.As(err, &co) {
+		t.Fatalf("expected *types.CircuitOpen, got %T: %v", err, err)
+	}
+	if got := atomic.LoadInt32(&n); got != 2 {
+		t.Fatalf("expected breaker to short-circuit without hitting the server, server saw %d requests", got)
+	}
+}
+
+func TestBreakerCooldownDoublesOnRepeatedProbeFailure(t *testing.T) {
+	var n int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n, 1)
+		w.WriteHeader(500)
+		_, _ = w.Write([]byte("boom"))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.Breaker = BreakerPolicy{FailureThreshold: 1, Window: time.Minute, Cooldown: 20 * time.Millisecond, MaxCooldown: time.Second}
+
+	tr := NewTransport(http.DefaultClient, p)
+
+	// Trip #1: Cooldown is 20ms.
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil); err == nil {
+		t.Fatalf("expected 500 error")
+	}
+
+	key := "GET " + mustURL(t, srv.URL).Host + "/orders"
+	if got := tr.breakers.cooldownRemaining(key); got <= 0 || got > 20*time.Millisecond {
+		t.Fatalf("expected ~20ms cooldown after first trip, got %v", got)
+	}
+
+	time.Sleep(25 * time.Millisecond)
+
+	// The admitted probe fails too: next cooldown should double to ~40ms.
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil); err == nil {
+		t.Fatalf("expected probe to fail with 500 error")
+	}
+	if got := tr.breakers.cooldownRemaining(key); got <= 20*time.Millisecond || got > 40*time.Millisecond {
+		t.Fatalf("expected ~40ms cooldown after second trip, got %v", got)
+	}
+}
+
+func TestBreakerClosesAfterProbeReturnsBusinessError(t *testing.T) {
+	var n int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&n, 1)
+		switch {
+		case count <= 2:
+			w.WriteHeader(500)
+			_, _ = w.Write([]byte("boom"))
+		case count == 3:
+			w.WriteHeader(400)
+			_, _ = w.Write([]byte("bad request"))
+		default:
+			w.WriteHeader(200)
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.Breaker = BreakerPolicy{FailureThreshold: 2, Window: time.Minute, Cooldown: 40 * time.Millisecond}
+
+	tr := NewTransport(http.DefaultClient, p)
+
+	for i := 0; i < 2; i++ {
+		if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil); err == nil {
+			t.Fatalf("attempt %d: expected 500 error", i)
+		}
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	// Cooldown has elapsed: the admitted probe gets a normal client/business
+	// error, unrelated to upstream health - it must still resolve the probe
+	// instead of leaving the breaker wedged in StateHalfOpen.
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil); err == nil {
+		t.Fatalf("expected 400 error from the probe")
+	}
+
+	// A subsequent normal call must be allowed through, not short-circuited.
+	out, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/orders", nil, nil)
+	if err != nil {
+		t.Fatalf("expected the breaker to have closed after the probe's business error, got: %v", err)
+	}
+	if string(out) != "ok" {
+		t.Fatalf("expected ok, got %q", string(out))
+	}
+	if got := atomic.LoadInt32(&n); got != 4 {
+		t.Fatalf("expected exactly 4 requests to hit the server, got %d", got)
+	}
+}
+
+func mustURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse %q: %v", raw, err)
+	}
+	return u
+}
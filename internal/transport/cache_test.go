@@ -0,0 +1,97 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/star-gazer111/poly-go-clob-client/cache"
+)
+
+func TestDoJSON_CacheHitSkipsNetwork(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	c := cache.NewMemoryCache()
+	defer c.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	tr := NewTransport(http.DefaultClient, p).WithCache(c, []cache.CacheRule{
+		{Method: http.MethodGet, PathPattern: "/markets/*", TTL: time.Minute},
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/markets/0x1", nil, nil); err != nil {
+			t.Fatalf("DoJSON call %d: %v", i, err)
+		}
+	}
+
+	if hits != 1 {
+		t.Fatalf("expected exactly 1 network hit, got %d", hits)
+	}
+}
+
+func TestDoJSON_NonMatchingPathBypassesCache(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	c := cache.NewMemoryCache()
+	defer c.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	tr := NewTransport(http.DefaultClient, p).WithCache(c, []cache.CacheRule{
+		{Method: http.MethodGet, PathPattern: "/markets/*", TTL: time.Minute},
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/ping", nil, nil); err != nil {
+			t.Fatalf("DoJSON call %d: %v", i, err)
+		}
+	}
+
+	if hits != 2 {
+		t.Fatalf("expected no caching for a non-matching path, got %d network hits", hits)
+	}
+}
+
+func TestDoJSON_ErrorResponsesAreNotCached(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := cache.NewMemoryCache()
+	defer c.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	tr := NewTransport(http.DefaultClient, p).WithCache(c, []cache.CacheRule{
+		{Method: http.MethodGet, PathPattern: "/markets/*", TTL: time.Minute},
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/markets/0x1", nil, nil); err == nil {
+			t.Fatalf("call %d: expected an error for a 500 response", i)
+		}
+	}
+
+	if hits != 2 {
+		t.Fatalf("expected every attempt to hit the network since nothing succeeded, got %d", hits)
+	}
+}
@@ -0,0 +1,237 @@
+package transport
+
+import (
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// EndpointStrategy selects how an EndpointPicker distributes attempts across
+// its configured endpoints.
+type EndpointStrategy int
+
+const (
+	// StrategyRoundRobin cycles through healthy endpoints in order.
+	StrategyRoundRobin EndpointStrategy = iota
+	// StrategyWeightedRandom picks a healthy endpoint at random, weighted by
+	// Endpoint.Weight.
+	StrategyWeightedRandom
+)
+
+// Endpoint is one candidate base URL (e.g. "https://clob-1.example.com") a
+// Transport may dial.
+type Endpoint struct {
+	URL string
+	// Weight only matters for StrategyWeightedRandom; <= 0 is treated as 1.
+	Weight int
+}
+
+// EndpointPicker selects which configured endpoint a given attempt should
+// dial, and is told the outcome afterward so it can demote flaky backends.
+// Callers may supply their own implementation (e.g. latency-based) via
+// clob.WithFailoverEndpoints or transport.Policy.EndpointPicker directly.
+type EndpointPicker interface {
+	// Pick returns the base URL to dial next.
+	Pick() (string, error)
+	// Report records the outcome of a call against endpoint. err is any
+	// network/timeout/circuit-open error; statusCode is the HTTP status of
+	// the response, 2xx or not (0 when err is non-nil).
+	Report(endpoint string, err error, statusCode int)
+	// Len reports how many endpoints are configured, so callers (like the
+	// retry loop) can bound how many times they re-pick after a failure.
+	Len() int
+}
+
+// EndpointPickerPolicy configures NewEndpointPicker.
+type EndpointPickerPolicy struct {
+	Strategy EndpointStrategy
+	// Cooldown is how long a failed endpoint is demoted before being
+	// reconsidered. Each consecutive failure doubles it, capped at
+	// MaxCooldown.
+	Cooldown time.Duration
+	// MaxCooldown caps the exponential growth of Cooldown. Zero means no
+	// cap: the cooldown keeps doubling on every consecutive failure.
+	MaxCooldown time.Duration
+	// OnStateChange, when set, is called whenever an endpoint transitions
+	// between healthy and demoted.
+	OnStateChange func(endpoint string, healthy bool)
+}
+
+func DefaultEndpointPickerPolicy() EndpointPickerPolicy {
+	return EndpointPickerPolicy{
+		Strategy:    StrategyRoundRobin,
+		Cooldown:    5 * time.Second,
+		MaxCooldown: time.Minute,
+	}
+}
+
+type endpointEntry struct {
+	mu               sync.Mutex
+	healthy          bool
+	demotedUntil     time.Time
+	consecutiveFails int
+}
+
+// roundRobinPicker is the default EndpointPicker: it demotes endpoints that
+// report network errors, 5xx, 429, or circuit-open outcomes with an
+// exponentially growing cooldown, and distributes attempts across whatever
+// remains healthy via round-robin or weighted-random selection.
+type roundRobinPicker struct {
+	mu        sync.Mutex
+	endpoints []Endpoint
+	entries   map[string]*endpointEntry
+	next      int
+	policy    EndpointPickerPolicy
+}
+
+// NewEndpointPicker builds the default EndpointPicker over endpoints. An
+// empty endpoints list disables failover entirely (returns nil), matching
+// how a zero-value BreakerPolicy disables the circuit breaker.
+func NewEndpointPicker(endpoints []Endpoint, policy EndpointPickerPolicy) EndpointPicker {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	p := &roundRobinPicker{
+		endpoints: endpoints,
+		entries:   make(map[string]*endpointEntry, len(endpoints)),
+		policy:    policy,
+	}
+	for _, ep := range endpoints {
+		p.entries[ep.URL] = &endpointEntry{healthy: true}
+	}
+	return p
+}
+
+func (p *roundRobinPicker) Len() int { return len(p.endpoints) }
+
+func (p *roundRobinPicker) Pick() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var healthy []Endpoint
+	for _, ep := range p.endpoints {
+		e := p.entries[ep.URL]
+		e.mu.Lock()
+		demoted := now.Before(e.demotedUntil)
+		e.mu.Unlock()
+		if !demoted {
+			healthy = append(healthy, ep)
+		}
+	}
+
+	// All endpoints are demoted: fail open onto whichever recovers soonest
+	// rather than refusing to dial at all.
+	if len(healthy) == 0 {
+		return p.soonestToRecover().URL, nil
+	}
+
+	if p.policy.Strategy == StrategyWeightedRandom {
+		return weightedPick(healthy).URL, nil
+	}
+
+	ep := healthy[p.next%len(healthy)]
+	p.next++
+	return ep.URL, nil
+}
+
+// soonestToRecover must be called with p.mu held.
+func (p *roundRobinPicker) soonestToRecover() Endpoint {
+	best := p.endpoints[0]
+	bestUntil := p.entries[best.URL].demotedUntil
+	for _, ep := range p.endpoints[1:] {
+		until := p.entries[ep.URL].demotedUntil
+		if until.Before(bestUntil) {
+			best, bestUntil = ep, until
+		}
+	}
+	return best
+}
+
+func weightedPick(endpoints []Endpoint) Endpoint {
+	total := 0
+	for _, ep := range endpoints {
+		total += weightOf(ep)
+	}
+	r := rand.Intn(total)
+	for _, ep := range endpoints {
+		r -= weightOf(ep)
+		if r < 0 {
+			return ep
+		}
+	}
+	return endpoints[len(endpoints)-1]
+}
+
+func weightOf(ep Endpoint) int {
+	if ep.Weight <= 0 {
+		return 1
+	}
+	return ep.Weight
+}
+
+func (p *roundRobinPicker) Report(endpoint string, err error, statusCode int) {
+	e, ok := p.entries[endpoint]
+	if !ok {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	failed := err != nil || statusCode >= 500 || statusCode == 429
+	wasHealthy := e.healthy
+
+	if !failed {
+		e.consecutiveFails = 0
+		e.demotedUntil = time.Time{}
+		e.healthy = true
+		if !wasHealthy && p.policy.OnStateChange != nil {
+			p.policy.OnStateChange(endpoint, true)
+		}
+		return
+	}
+
+	e.consecutiveFails++
+	e.demotedUntil = time.Now().Add(p.cooldownFor(e.consecutiveFails))
+	e.healthy = false
+	if wasHealthy && p.policy.OnStateChange != nil {
+		p.policy.OnStateChange(endpoint, false)
+	}
+}
+
+// cooldownFor returns the cooldown for the nth (1-indexed) consecutive
+// failure: Cooldown, 2x, 4x, ... capped at MaxCooldown (if set).
+func (p *roundRobinPicker) cooldownFor(consecutiveFails int) time.Duration {
+	d := p.policy.Cooldown
+	for i := 1; i < consecutiveFails; i++ {
+		d *= 2
+		if p.policy.MaxCooldown > 0 && d >= p.policy.MaxCooldown {
+			return p.policy.MaxCooldown
+		}
+	}
+	if p.policy.MaxCooldown > 0 && d > p.policy.MaxCooldown {
+		d = p.policy.MaxCooldown
+	}
+	return d
+}
+
+// retargetEndpoint rewrites req's scheme and host to point at base (e.g.
+// "https://clob-2.example.com"), leaving path, query, and fragment intact.
+func retargetEndpoint(req *http.Request, base string) error {
+	u, err := url.Parse(base)
+	if err != nil {
+		return err
+	}
+	req.URL.Scheme = u.Scheme
+	req.URL.Host = u.Host
+	return nil
+}
+
+// currentEndpoint reports the scheme+host req is currently targeting, i.e.
+// the endpoint the last attempt was (or is about to be) dialed against.
+func currentEndpoint(req *http.Request) string {
+	return req.URL.Scheme + "://" + req.URL.Host
+}
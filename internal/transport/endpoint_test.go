@@ -0,0 +1,190 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func newCountingServer(status int) (*httptest.Server, *int32) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(status)
+	}))
+	return srv, &hits
+}
+
+func TestEndpointPicker_RoundRobinDistributesAcrossHealthyEndpoints(t *testing.T) {
+	srvA, hitsA := newCountingServer(http.StatusOK)
+	defer srvA.Close()
+	srvB, hitsB := newCountingServer(http.StatusOK)
+	defer srvB.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	picker := NewEndpointPicker([]Endpoint{{URL: srvA.URL}, {URL: srvB.URL}}, DefaultEndpointPickerPolicy())
+	tr := NewTransport(http.DefaultClient, p).WithEndpointPicker(picker)
+
+	for i := 0; i < 4; i++ {
+		if _, err := tr.DoJSON(context.Background(), http.MethodGet, srvA.URL+"/ping", nil, nil); err != nil {
+			t.Fatalf("attempt %d: %v", i, err)
+		}
+	}
+
+	if got := atomic.LoadInt32(hitsA); got != 2 {
+		t.Fatalf("expected endpoint A to see 2 hits, got %d", got)
+	}
+	if got := atomic.LoadInt32(hitsB); got != 2 {
+		t.Fatalf("expected endpoint B to see 2 hits, got %d", got)
+	}
+}
+
+func TestEndpointPicker_DemotesFailingEndpointAndRoutesAroundIt(t *testing.T) {
+	srvBad, hitsBad := newCountingServer(http.StatusInternalServerError)
+	defer srvBad.Close()
+	srvGood, hitsGood := newCountingServer(http.StatusOK)
+	defer srvGood.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.Breaker = BreakerPolicy{} // isolate endpoint-level demotion from the breaker
+
+	policy := DefaultEndpointPickerPolicy()
+	policy.Cooldown = time.Minute // long enough that it won't recover mid-test
+	picker := NewEndpointPicker([]Endpoint{{URL: srvBad.URL}, {URL: srvGood.URL}}, policy)
+	tr := NewTransport(http.DefaultClient, p).WithEndpointPicker(picker)
+
+	// First attempt round-robins onto srvBad and fails; subsequent calls
+	// should avoid it once it's been reported unhealthy.
+	_, _ = tr.DoJSON(context.Background(), http.MethodGet, srvBad.URL+"/ping", nil, nil)
+
+	for i := 0; i < 4; i++ {
+		if _, err := tr.DoJSON(context.Background(), http.MethodGet, srvBad.URL+"/ping", nil, nil); err != nil {
+			t.Fatalf("attempt %d: expected success routed to healthy endpoint, got %v", i, err)
+		}
+	}
+
+	if got := atomic.LoadInt32(hitsBad); got != 1 {
+		t.Fatalf("expected srvBad to see exactly 1 hit (then be demoted), got %d", got)
+	}
+	if got := atomic.LoadInt32(hitsGood); got != 4 {
+		t.Fatalf("expected srvGood to absorb the remaining 4 requests, got %d", got)
+	}
+}
+
+func TestEndpointPicker_CircuitOpenEndpointIsRoutedAround(t *testing.T) {
+	srvBad, hitsBad := newCountingServer(http.StatusInternalServerError)
+	defer srvBad.Close()
+	srvGood, hitsGood := newCountingServer(http.StatusOK)
+	defer srvGood.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.Breaker = BreakerPolicy{FailureThreshold: 1, Window: time.Minute, Cooldown: time.Minute}
+
+	policy := DefaultEndpointPickerPolicy()
+	policy.Cooldown = time.Millisecond // recovers almost immediately at the endpoint-picker level
+	picker := NewEndpointPicker([]Endpoint{{URL: srvBad.URL}, {URL: srvGood.URL}}, policy)
+	tr := NewTransport(http.DefaultClient, p).WithEndpointPicker(picker)
+
+	// Trip srvBad's breaker.
+	_, _ = tr.DoJSON(context.Background(), http.MethodGet, srvBad.URL+"/ping", nil, nil)
+	if got := atomic.LoadInt32(hitsBad); got != 1 {
+		t.Fatalf("expected 1 hit to trip the breaker, got %d", got)
+	}
+
+	time.Sleep(5 * time.Millisecond) // let the endpoint-picker cooldown lapse so srvBad is "healthy" again
+
+	// The first call left the round-robin cursor on srvGood; step past it so
+	// the next call picks srvBad first.
+	_, _ = picker.Pick()
+
+	// The next call round-robins onto srvBad; its breaker is open, so the
+	// retry loop should report that as a failure and fall through to
+	// srvGood without ever dialing srvBad again.
+	out, err := tr.DoJSON(context.Background(), http.MethodGet, srvBad.URL+"/ping", nil, nil)
+	if err != nil {
+		t.Fatalf("expected the request to succeed via fallback endpoint, got %v", err)
+	}
+	_ = out
+
+	if got := atomic.LoadInt32(hitsBad); got != 1 {
+		t.Fatalf("expected srvBad to stay at 1 hit (breaker short-circuited it), got %d", got)
+	}
+	if got := atomic.LoadInt32(hitsGood); got != 1 {
+		t.Fatalf("expected srvGood to receive the fallback request, got %d", got)
+	}
+}
+
+func TestEndpointPicker_WeightedRandomFavorsHigherWeight(t *testing.T) {
+	srvA, hitsA := newCountingServer(http.StatusOK)
+	defer srvA.Close()
+	srvB, hitsB := newCountingServer(http.StatusOK)
+	defer srvB.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.RateLimiter = rate.NewLimiter(rate.Inf, 0) // this test issues 200 rapid requests
+	policy := EndpointPickerPolicy{Strategy: StrategyWeightedRandom, Cooldown: time.Minute}
+	picker := NewEndpointPicker([]Endpoint{{URL: srvA.URL, Weight: 9}, {URL: srvB.URL, Weight: 1}}, policy)
+	tr := NewTransport(http.DefaultClient, p).WithEndpointPicker(picker)
+
+	const n = 200
+	for i := 0; i < n; i++ {
+		if _, err := tr.DoJSON(context.Background(), http.MethodGet, srvA.URL+"/ping", nil, nil); err != nil {
+			t.Fatalf("attempt %d: %v", i, err)
+		}
+	}
+
+	a, b := atomic.LoadInt32(hitsA), atomic.LoadInt32(hitsB)
+	if a+b != n {
+		t.Fatalf("expected %d total hits, got %d", n, a+b)
+	}
+	// Weight 9:1 should skew heavily toward A; assert it's at least 2x B's
+	// share to keep this robust against the RNG without being a no-op check.
+	if a < b*2 {
+		t.Fatalf("expected endpoint A (weight 9) to dominate endpoint B (weight 1), got A=%d B=%d", a, b)
+	}
+}
+
+func TestEndpointPicker_NoEndpointsDisablesFailover(t *testing.T) {
+	if picker := NewEndpointPicker(nil, DefaultEndpointPickerPolicy()); picker != nil {
+		t.Fatalf("expected nil picker for empty endpoint list, got %v", picker)
+	}
+}
+
+func TestEndpointPicker_OnStateChangeFiresOnDemotion(t *testing.T) {
+	srvBad, _ := newCountingServer(http.StatusInternalServerError)
+	defer srvBad.Close()
+
+	var mu sync.Mutex
+	var transitions []bool
+	policy := DefaultEndpointPickerPolicy()
+	policy.Cooldown = 5 * time.Millisecond
+	policy.OnStateChange = func(endpoint string, healthy bool) {
+		mu.Lock()
+		transitions = append(transitions, healthy)
+		mu.Unlock()
+	}
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	picker := NewEndpointPicker([]Endpoint{{URL: srvBad.URL}}, policy)
+	tr := NewTransport(http.DefaultClient, p).WithEndpointPicker(picker)
+
+	_, _ = tr.DoJSON(context.Background(), http.MethodGet, srvBad.URL+"/ping", nil, nil)
+
+	mu.Lock()
+	got := append([]bool(nil), transitions...)
+	mu.Unlock()
+	if len(got) != 1 || got[0] != false {
+		t.Fatalf("expected a single demotion transition, got %v", got)
+	}
+}
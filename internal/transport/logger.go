@@ -0,0 +1,18 @@
+package transport
+
+import (
+	"net/http"
+	"time"
+)
+
+// Logger receives redacted request/response lifecycle events tagged with
+// each call's request ID, so operators can correlate retries and failures
+// across log lines without re-deriving which attempt produced which line.
+// headers passed to OnRequest/OnResponse have already been through
+// Policy.RedactionPolicy's RedactHeaders (redaction.DefaultPolicy when
+// unset).
+type Logger interface {
+	OnRequest(requestID, method, url string, headers http.Header)
+	OnResponse(requestID string, statusCode int, headers http.Header, err error)
+	OnRetry(requestID, reason string, nextDelay time.Duration)
+}
@@ -0,0 +1,158 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/star-gazer111/poly-go-clob-client/internal/redaction"
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+// recordingLogger collects the events fired through it, guarded by mu since
+// DoJSON may call it from retry attempts in quick succession.
+type recordingLogger struct {
+	mu        sync.Mutex
+	requests  []string
+	responses []int
+	lastErr   error
+	retries   int
+}
+
+func (l *recordingLogger) OnRequest(requestID, method, url string, headers http.Header) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.requests = append(l.requests, requestID)
+	if headers.Get("X-Request-Id") != requestID {
+		panic("expected OnRequest headers to carry the same X-Request-Id")
+	}
+}
+
+func (l *recordingLogger) OnResponse(requestID string, statusCode int, headers http.Header, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.responses = append(l.responses, statusCode)
+	l.lastErr = err
+}
+
+func (l *recordingLogger) OnRetry(requestID, reason string, nextDelay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.retries++
+}
+
+func TestLogger_FiresOnRequestAndOnResponseForSuccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer srv.Close()
+
+	log := &recordingLogger{}
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	tr := NewTransport(http.DefaultClient, p).WithLogger(log)
+
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/ping", nil, nil); err != nil {
+		t.Fatalf("DoJSON: %v", err)
+	}
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	if len(log.requests) != 1 || len(log.responses) != 1 {
+		t.Fatalf("expected exactly one OnRequest/OnResponse pair, got %d/%d", len(log.requests), len(log.responses))
+	}
+	if log.responses[0] != 200 {
+		t.Fatalf("expected status 200, got %d", log.responses[0])
+	}
+	if log.lastErr != nil {
+		t.Fatalf("expected no error on success, got %v", log.lastErr)
+	}
+}
+
+func TestLogger_FiresOnRetryAndTagsErrorWithRequestID(t *testing.T) {
+	var attempt int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempt, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	log := &recordingLogger{}
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 1
+	p.Retry.BaseDelay = time.Millisecond
+	tr := NewTransport(http.DefaultClient, p).WithLogger(log)
+
+	ctx := ContextWithRequestID(context.Background(), "01FIXEDREQUESTID")
+	_, err := tr.DoJSON(ctx, http.MethodGet, srv.URL+"/ping", nil, nil)
+	if err == nil {
+		t.Fatalf("expected an error after exhausting retries")
+	}
+
+	var statusErr *types.Error
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected *types.Error, got %T", err)
+	}
+	if statusErr.RequestID() != "01FIXEDREQUESTID" {
+		t.Fatalf("expected error tagged with the ctx request ID, got %q", statusErr.RequestID())
+	}
+
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	if log.retries != 1 {
+		t.Fatalf("expected exactly one retry event, got %d", log.retries)
+	}
+	for _, id := range log.requests {
+		if id != "01FIXEDREQUESTID" {
+			t.Fatalf("expected every OnRequest to carry the ctx request ID, got %q", id)
+		}
+	}
+}
+
+// headerCapturingLogger records the last headers OnRequest saw, so a test
+// can assert on how they were redacted.
+type headerCapturingLogger struct {
+	lastHeaders http.Header
+}
+
+func (l *headerCapturingLogger) OnRequest(requestID, method, url string, headers http.Header) {
+	l.lastHeaders = headers
+}
+func (l *headerCapturingLogger) OnResponse(requestID string, statusCode int, headers http.Header, err error) {
+}
+func (l *headerCapturingLogger) OnRetry(requestID, reason string, nextDelay time.Duration) {}
+
+func TestLogger_UsesCustomRedactionPolicy(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	log := &headerCapturingLogger{}
+	rp := redaction.DefaultPolicy()
+	rp.SensitiveSubstrings = append(rp.SensitiveSubstrings, "vendor")
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.RedactionPolicy = rp
+	tr := NewTransport(http.DefaultClient, p).WithLogger(log)
+
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/ping", map[string]string{
+		"X-Vendor-Credential": "abcdefghij",
+	}, nil); err != nil {
+		t.Fatalf("DoJSON: %v", err)
+	}
+
+	if log.lastHeaders.Get("X-Vendor-Credential") != "***" {
+		t.Fatalf("expected custom RedactionPolicy to redact X-Vendor-Credential, got=%q", log.lastHeaders.Get("X-Vendor-Credential"))
+	}
+}
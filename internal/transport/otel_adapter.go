@@ -0,0 +1,61 @@
+package transport
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Span is a minimal span abstraction shaped after otelhttp: set attributes
+// as the request progresses, then End it once. This package has no
+// dependency on go.opentelemetry.io/otel (none is vendored in go.mod), so a
+// real OTel span can satisfy this interface with a thin wrapper - e.g.
+// SetAttribute delegating to trace.Span.SetAttributes and End to
+// trace.Span.End - letting callers bridge to actual OTel without Transport
+// importing it directly.
+type Span interface {
+	SetAttribute(key string, value any)
+	End()
+}
+
+// SpanStarter starts one Span for a request attempt, analogous to an
+// otelhttp tracer's Start method.
+type SpanStarter func(req *http.Request) Span
+
+// OtelSpanAdapter builds a Trace that opens one Span per attempt via start
+// and annotates it with the attributes an otelhttp-style integration would
+// expect: http.method, http.status_code, retry.count, and poly.endpoint
+// (the host actually dialed, which may differ from the request's original
+// host once multi-endpoint failover has re-targeted it).
+func OtelSpanAdapter(start SpanStarter) *Trace {
+	var mu sync.Mutex
+	open := make(map[*http.Request]Span)
+
+	return &Trace{
+		OnAttemptStart: func(attempt int, req *http.Request) {
+			span := start(req)
+			span.SetAttribute("http.method", req.Method)
+			span.SetAttribute("poly.endpoint", req.URL.Host)
+			span.SetAttribute("retry.count", attempt-1)
+
+			mu.Lock()
+			open[req] = span
+			mu.Unlock()
+		},
+		OnAttemptEnd: func(req *http.Request, attempt int, statusCode int, err error, dur time.Duration, timings Timings) {
+			mu.Lock()
+			span, ok := open[req]
+			delete(open, req)
+			mu.Unlock()
+			if !ok {
+				return
+			}
+
+			span.SetAttribute("http.status_code", statusCode)
+			if err != nil {
+				span.SetAttribute("error", err.Error())
+			}
+			span.End()
+		},
+	}
+}
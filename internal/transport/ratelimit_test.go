@@ -0,0 +1,91 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/star-gazer111/poly-go-clob-client/ratelimit"
+)
+
+// TestEndpointLimiterThrottlesBeforeDialing asserts that a configured
+// per-endpoint bucket paces requests even though the server itself never
+// throttles: the second request against an exhausted bucket should block
+// roughly until the bucket refills.
+func TestEndpointLimiterThrottlesBeforeDialing(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	tr := NewTransport(http.DefaultClient, p).WithEndpointLimiter(ratelimit.NewLimiter(ratelimit.Policy{
+		Endpoints: []ratelimit.EndpointLimit{{Prefix: "/book", Limit: rate.Every(80 * time.Millisecond), Burst: 1}},
+	}))
+
+	start := time.Now()
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/book", nil, nil); err != nil {
+		t.Fatalf("first DoJSON: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Fatalf("first request should consume the initial burst instantly, took %v", elapsed)
+	}
+
+	start = time.Now()
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/book", nil, nil); err != nil {
+		t.Fatalf("second DoJSON: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Fatalf("second request should have waited for a bucket refill, took %v", elapsed)
+	}
+
+	if hits != 2 {
+		t.Fatalf("expected 2 requests to reach the server, got %d", hits)
+	}
+}
+
+// TestEndpointLimiterCancellationPropagates asserts that a context deadline
+// shorter than the bucket's refill time surfaces a *ratelimit.RateLimitError
+// instead of dialing the server.
+func TestEndpointLimiterCancellationPropagates(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0 // isolate a single attempt so the limiter error isn't masked by a retry
+	tr := NewTransport(http.DefaultClient, p).WithEndpointLimiter(ratelimit.NewLimiter(ratelimit.Policy{
+		Endpoints: []ratelimit.EndpointLimit{{Prefix: "/order", Limit: rate.Every(time.Hour), Burst: 1}},
+	}))
+
+	// Consume the single burst token.
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/order", nil, nil); err != nil {
+		t.Fatalf("first DoJSON: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := tr.DoJSON(ctx, http.MethodGet, srv.URL+"/order", nil, nil)
+	if err == nil {
+		t.Fatal("expected the exhausted bucket to return an error")
+	}
+	var rlErr *ratelimit.RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("expected a *ratelimit.RateLimitError, got %T: %v", err, err)
+	}
+	if hits != 1 {
+		t.Fatalf("expected the second request to never reach the server, got %d hits", hits)
+	}
+}
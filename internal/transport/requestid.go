@@ -0,0 +1,63 @@
+package transport
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+)
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying id as the request ID
+// for any transport call made with it, taking precedence over the
+// Transport's own RequestIDGenerator. Useful for propagating an ID already
+// assigned upstream (e.g. from an inbound request) instead of minting a
+// fresh one.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID ctx carries, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newRequestID generates a ULID-shaped ID: a 48-bit millisecond timestamp
+// followed by 80 bits of randomness, Crockford base32 encoded. It sorts
+// lexically by generation time like a real ULID, without pulling in a ULID
+// dependency for what's otherwise just a correlation token.
+func newRequestID() string {
+	var b [16]byte
+	ms := uint64(time.Now().UnixMilli())
+	b[0] = byte(ms >> 40)
+	b[1] = byte(ms >> 32)
+	b[2] = byte(ms >> 24)
+	b[3] = byte(ms >> 16)
+	b[4] = byte(ms >> 8)
+	b[5] = byte(ms)
+	_, _ = rand.Read(b[6:])
+	return encodeCrockford(b[:])
+}
+
+// encodeCrockford base32-encodes b using the Crockford alphabet (no padding,
+// excludes I/L/O/U to avoid visual ambiguity), the same encoding ULIDs use.
+func encodeCrockford(b []byte) string {
+	out := make([]byte, 0, (len(b)*8+4)/5)
+	var buf uint64
+	var bits uint
+	for _, c := range b {
+		buf = buf<<8 | uint64(c)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out = append(out, crockfordAlphabet[(buf>>bits)&0x1F])
+		}
+	}
+	if bits > 0 {
+		out = append(out, crockfordAlphabet[(buf<<(5-bits))&0x1F])
+	}
+	return string(out)
+}
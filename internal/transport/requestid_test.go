@@ -0,0 +1,43 @@
+package transport
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewRequestID_ShapeAndAlphabet(t *testing.T) {
+	id := newRequestID()
+	if len(id) != 26 {
+		t.Fatalf("expected a 26-char ULID-shaped ID, got %d chars: %q", len(id), id)
+	}
+	for _, r := range id {
+		if !strings.ContainsRune(crockfordAlphabet, r) {
+			t.Fatalf("unexpected character %q in request ID %q", r, id)
+		}
+	}
+}
+
+func TestNewRequestID_DistinctAcrossCalls(t *testing.T) {
+	seen := make(map[string]struct{})
+	for i := 0; i < 100; i++ {
+		id := newRequestID()
+		if _, dup := seen[id]; dup {
+			t.Fatalf("newRequestID produced a duplicate: %q", id)
+		}
+		seen[id] = struct{}{}
+	}
+}
+
+func TestContextWithRequestID_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := RequestIDFromContext(ctx); ok {
+		t.Fatalf("expected no request ID on a bare context")
+	}
+
+	ctx = ContextWithRequestID(ctx, "01TESTID")
+	id, ok := RequestIDFromContext(ctx)
+	if !ok || id != "01TESTID" {
+		t.Fatalf("expected round-tripped request ID 01TESTID, got %q (ok=%v)", id, ok)
+	}
+}
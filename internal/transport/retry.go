@@ -3,17 +3,38 @@ package transport
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"time"
 
+	"github.com/star-gazer111/poly-go-clob-client/internal/redaction"
 	"github.com/star-gazer111/poly-go-clob-client/types"
 )
 
+// Jitter selects how sleepBackoff randomizes the computed exponential delay,
+// in the style described in AWS's "Exponential Backoff And Jitter" post.
+type Jitter int
+
+const (
+	// JitterNone sleeps for exactly the computed delay every time - this is
+	// what synchronizes retrying clients into a thundering herd.
+	JitterNone Jitter = iota
+	// JitterFull samples uniformly from [0, delay].
+	JitterFull
+	// JitterEqual samples uniformly from [delay/2, delay/2 + rand(delay/2)],
+	// keeping half the backoff while still spreading attempts out.
+	JitterEqual
+)
+
 type RetryPolicy struct {
 	MaxRetries int
 	BaseDelay  time.Duration
 	MaxDelay   time.Duration
+	// Jitter controls how the computed exponential delay is randomized
+	// before sleeping. Defaults to JitterNone (the zero value).
+	Jitter Jitter
 }
 
 func DefaultRetryPolicy() RetryPolicy {
@@ -21,6 +42,7 @@ func DefaultRetryPolicy() RetryPolicy {
 		MaxRetries: 2,
 		BaseDelay:  150 * time.Millisecond,
 		MaxDelay:   2 * time.Second,
+		Jitter:     JitterFull,
 	}
 }
 
@@ -91,11 +113,18 @@ func bodyPreview(b []byte) string {
 
 // doJSONWithRetry performs the request via Transport.Do and returns the raw response body.
 // On non-2xx it returns a typed error compatible with:
-//   errors.As(err, *types.Status)
-//   errors.As(err, *types.Error) with KindStatus
-func doJSONWithRetry(ctx context.Context, t *Transport, req *http.Request) ([]byte, error) {
+//
+//	errors.As(err, *types.Status)
+//	errors.As(err, *types.Error) with KindStatus
+func doJSONWithRetry(ctx context.Context, t *Transport, req *http.Request, body []byte, requestID string) ([]byte, error) {
 	p := t.policy.Retry
+	logger := t.policy.Logger
+	rp := t.policy.RedactionPolicy
+	if rp == nil {
+		rp = redaction.DefaultPolicy()
+	}
 	attempts := 0
+	endpointSwitches := 0
 
 	// Make sure the request body can be replayed.
 	if err := ensureReplayableBody(req); err != nil {
@@ -103,19 +132,74 @@ func doJSONWithRetry(ctx context.Context, t *Transport, req *http.Request) ([]by
 	}
 
 	for {
+		if t.policy.EndpointPicker != nil {
+			ep, perr := t.policy.EndpointPicker.Pick()
+			if perr != nil {
+				return nil, types.WithSource(types.KindInternal, perr)
+			}
+			if err := retargetEndpoint(req, ep); err != nil {
+				return nil, types.WithSource(types.KindInternal, err)
+			}
+		}
+
+		key := breakerKey(req)
+
+		if !t.breakers.allow(key) {
+			if tr := t.policy.Trace; tr != nil && tr.OnCircuitOpen != nil {
+				tr.OnCircuitOpen(key)
+			}
+			// With failover configured, a circuit-open endpoint is just
+			// another failure to route around rather than a hard stop:
+			// demote it and re-pick, bounded by the number of endpoints so
+			// we don't spin forever when all of them are open.
+			if t.policy.EndpointPicker != nil && endpointSwitches < t.policy.EndpointPicker.Len()-1 {
+				t.policy.EndpointPicker.Report(currentEndpoint(req), types.ErrEndpointCircuitOpen, 0)
+				endpointSwitches++
+				continue
+			}
+			return nil, types.CircuitOpenErr(key, t.breakers.cooldownRemaining(key), t.breakers.lastStatusFor(key))
+		}
+
 		// Reset body before each attempt so retries send the same payload.
 		if err := resetBody(req); err != nil {
 			return nil, types.WithSource(types.KindInternal, err)
 		}
 
-		resp, err := t.Do(ctx, req)
+		if t.policy.Mutate != nil {
+			if err := t.policy.Mutate(req, body); err != nil {
+				return nil, types.WithSource(types.KindInternal, err)
+			}
+		}
+
+		attemptNum := attempts + 1
+		tr := t.policy.Trace
+		if tr != nil && tr.OnAttemptStart != nil {
+			tr.OnAttemptStart(attemptNum, req)
+		}
+		if logger != nil {
+			logger.OnRequest(requestID, req.Method, req.URL.String(), rp.RedactHeaders(req.Header))
+		}
+		dialCtx, timings := withClientTrace(ctx, tr)
+		attemptStart := time.Now()
+
+		resp, err := t.Do(dialCtx, req)
 		if err != nil {
+			if tr != nil && tr.OnAttemptEnd != nil {
+				tr.OnAttemptEnd(req, attemptNum, 0, err, time.Since(attemptStart), readTimings(timings))
+			}
+			if logger != nil {
+				logger.OnResponse(requestID, 0, nil, err)
+			}
+			t.breakers.recordFailure(key, nil)
+			if t.policy.EndpointPicker != nil {
+				t.policy.EndpointPicker.Report(currentEndpoint(req), err, 0)
+			}
 			// Only retry network-ish failures for idempotent methods.
 			if !isIdempotent(req.Method) || attempts >= p.MaxRetries {
 				return nil, types.WithSource(types.KindInternal, err)
 			}
 			attempts++
-			sleepBackoff(ctx, p, attempts)
+			sleepBackoff(ctx, p, attempts, retryCallback(tr, logger, requestID, "network_error"))
 			continue
 		}
 
@@ -124,20 +208,85 @@ func doJSONWithRetry(ctx context.Context, t *Transport, req *http.Request) ([]by
 		resp.Body.Close()
 
 		if rerr != nil {
+			if tr != nil && tr.OnAttemptEnd != nil {
+				tr.OnAttemptEnd(req, attemptNum, resp.StatusCode, rerr, time.Since(attemptStart), readTimings(timings))
+			}
+			if logger != nil {
+				logger.OnResponse(requestID, resp.StatusCode, rp.RedactHeaders(resp.Header), rerr)
+			}
 			return nil, types.WithSource(types.KindInternal, rerr)
 		}
 
 		// Your Transport.Do wraps the body with MaxBodyBytes+1. Detect overflow here.
 		if t.MaxBodyBytes() > 0 && int64(len(b)) > t.MaxBodyBytes() {
+			if tr != nil && tr.OnAttemptEnd != nil {
+				tr.OnAttemptEnd(req, attemptNum, resp.StatusCode, types.ErrBodyTooLarge, time.Since(attemptStart), readTimings(timings))
+			}
+			if logger != nil {
+				logger.OnResponse(requestID, resp.StatusCode, rp.RedactHeaders(resp.Header), types.ErrBodyTooLarge)
+			}
 			return nil, types.WithSource(types.KindInternal, types.ErrBodyTooLarge)
 		}
 
 		if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+			if tr != nil && tr.OnAttemptEnd != nil {
+				tr.OnAttemptEnd(req, attemptNum, resp.StatusCode, nil, time.Since(attemptStart), readTimings(timings))
+			}
+			if logger != nil {
+				logger.OnResponse(requestID, resp.StatusCode, rp.RedactHeaders(resp.Header), nil)
+			}
+			t.breakers.recordSuccess(key)
+			if t.policy.EndpointPicker != nil {
+				t.policy.EndpointPicker.Report(currentEndpoint(req), nil, resp.StatusCode)
+			}
+			if rateLimitExhausted(resp.Header) {
+				if d, ok := parseRetryAfter(resp.Header); ok {
+					t.rl.arm(d)
+				}
+			}
 			return b, nil
 		}
 
-		// Build a structured Status error (KindStatus) like Rust.
-		statusErr := types.StatusErr(resp.StatusCode, req.Method, req.URL.Path, bodyPreview(b))
+		// Build a structured Status error (KindStatus) like Rust, surfacing
+		// any server-provided Retry-After/X-RateLimit-Reset hint.
+		retryAfter, haveRetryAfter := parseRetryAfter(resp.Header)
+
+		// Classify parses the documented business "code" field (if any) out
+		// of the body; folding it into Status.Code lets errors.Is/IsCode
+		// branch on it without every caller re-parsing the body.
+		var code types.ErrorCode
+		if ae, ok := types.AsAPIError(types.Classify(resp.StatusCode, b)); ok {
+			code = ae.Code
+		}
+
+		status := &types.Status{
+			StatusCode: resp.StatusCode,
+			Method:     req.Method,
+			Path:       req.URL.Path,
+			Message:    bodyPreview(b),
+			RetryAfter: retryAfter,
+			Code:       code,
+		}
+		statusErr := types.WithSource(types.KindStatus, status).WithRequestID(requestID)
+
+		if tr != nil && tr.OnAttemptEnd != nil {
+			tr.OnAttemptEnd(req, attemptNum, resp.StatusCode, statusErr, time.Since(attemptStart), readTimings(timings))
+		}
+		if logger != nil {
+			logger.OnResponse(requestID, resp.StatusCode, rp.RedactHeaders(resp.Header), statusErr)
+		}
+
+		if resp.StatusCode >= 500 || resp.StatusCode == 429 {
+			t.breakers.recordFailure(key, status)
+		} else {
+			// A client/business error (400/401/404/...) still means the
+			// upstream answered, so resolve any in-flight half-open probe
+			// instead of leaving it stuck forever - see resolveProbe.
+			t.breakers.resolveProbe(key)
+		}
+		if t.policy.EndpointPicker != nil {
+			t.policy.EndpointPicker.Report(currentEndpoint(req), nil, resp.StatusCode)
+		}
 
 		// Retry only on idempotent + transient status.
 		if !isIdempotent(req.Method) || attempts >= p.MaxRetries || !shouldRetryStatus(resp.StatusCode) {
@@ -145,11 +294,78 @@ func doJSONWithRetry(ctx context.Context, t *Transport, req *http.Request) ([]by
 		}
 
 		attempts++
-		sleepBackoff(ctx, p, attempts)
+		reason := fmt.Sprintf("status_%d", resp.StatusCode)
+		if (resp.StatusCode == 429 || resp.StatusCode == 503) && haveRetryAfter {
+			sleepRetryAfter(ctx, p, retryAfter, retryCallback(tr, logger, requestID, reason))
+		} else {
+			sleepBackoff(ctx, p, attempts, retryCallback(tr, logger, requestID, reason))
+		}
 	}
 }
 
-func sleepBackoff(ctx context.Context, p RetryPolicy, attempt int) {
+// retryCallback adapts Trace.OnRetry and Logger.OnRetry into the onDelay
+// shape sleepBackoff and sleepRetryAfter expect, returning nil when neither
+// is configured so callers don't need their own nil checks.
+func retryCallback(tr *Trace, logger Logger, requestID, reason string) func(time.Duration) {
+	if (tr == nil || tr.OnRetry == nil) && logger == nil {
+		return nil
+	}
+	return func(d time.Duration) {
+		if tr != nil && tr.OnRetry != nil {
+			tr.OnRetry(reason, d)
+		}
+		if logger != nil {
+			logger.OnRetry(requestID, reason, d)
+		}
+	}
+}
+
+// sleepRetryAfter waits for the server-provided hint in place of the
+// computed exponential backoff, capped by MaxDelay. It deliberately does not
+// apply Jitter: the server already told us exactly when it wants to be
+// asked again. onDelay, if non-nil, is called with the delay actually used
+// before the sleep begins.
+func sleepRetryAfter(ctx context.Context, p RetryPolicy, hint time.Duration, onDelay func(time.Duration)) {
+	delay := hint
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if onDelay != nil {
+		onDelay(delay)
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-t.C:
+		return
+	}
+}
+
+// jitter applies p.Jitter to the computed exponential delay. JitterNone
+// (the zero value) returns delay unchanged.
+func jitter(p RetryPolicy, delay time.Duration) time.Duration {
+	if delay <= 0 {
+		return delay
+	}
+	switch p.Jitter {
+	case JitterFull:
+		return time.Duration(rand.Int63n(int64(delay) + 1))
+	case JitterEqual:
+		half := delay / 2
+		return half + time.Duration(rand.Int63n(int64(half)+1))
+	default:
+		return delay
+	}
+}
+
+// sleepBackoff sleeps the computed exponential delay for attempt, with
+// Jitter applied. onDelay, if non-nil, is called with the delay actually
+// used before the sleep begins.
+func sleepBackoff(ctx context.Context, p RetryPolicy, attempt int, onDelay func(time.Duration)) {
 	if attempt <= 0 {
 		attempt = 1
 	}
@@ -179,6 +395,11 @@ func sleepBackoff(ctx context.Context, p RetryPolicy, attempt int) {
 		delay = p.MaxDelay
 	}
 
+	delay = jitter(p, delay)
+	if onDelay != nil {
+		onDelay(delay)
+	}
+
 	t := time.NewTimer(delay)
 	defer t.Stop()
 
@@ -57,6 +57,41 @@ func TestNon2xxReturnsTypedStatusAndKind(t *testing.T) {
 	}
 }
 
+func TestNon2xxCarriesBusinessCodeForIsAndIsCode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"order would match", "code":"POST_ONLY_WOULD_MATCH"}`))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	tr := NewTransport(http.DefaultClient, p)
+
+	_, err := tr.DoJSON(context.Background(), http.MethodPost, srv.URL+"/orders", nil, nil)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+
+	if !errors.Is(err, types.ErrPostOnlyReject) {
+		t.Fatalf("expected errors.Is to match ErrPostOnlyReject, got: %T %v", err, err)
+	}
+	if !errors.Is(err, types.ErrBadRequest) {
+		t.Fatalf("expected errors.Is to match the 400 status bucket ErrBadRequest, got: %T %v", err, err)
+	}
+	if !types.IsCode(err, types.ErrorCodePostOnlyWouldMatch) {
+		t.Fatalf("expected types.IsCode(err, ErrorCodePostOnlyWouldMatch) to be true")
+	}
+
+	var st *types.Status
+	if !errors.As(err, &st) {
+		t.Fatalf("expected errors.As to find *types.Status, got: %T %v", err, err)
+	}
+	if st.Code != types.ErrorCodePostOnlyWouldMatch {
+		t.Fatalf("expected Status.Code to carry the parsed code, got %q", st.Code)
+	}
+}
+
 func TestRetryReplaysBodyOnRetryForPUT(t *testing.T) {
 	var n int32
 	var firstBody, secondBody []byte
@@ -105,3 +140,61 @@ func TestRetryReplaysBodyOnRetryForPUT(t *testing.T) {
 		t.Fatalf("second body mismatch (replay failed): %q", string(secondBody))
 	}
 }
+
+func TestJitterStaysWithinBounds(t *testing.T) {
+	delay := 100 * time.Millisecond
+
+	for i := 0; i < 200; i++ {
+		p := RetryPolicy{Jitter: JitterFull}
+		got := jitter(p, delay)
+		if got < 0 || got > delay {
+			t.Fatalf("JitterFull: got %v, want in [0, %v]", got, delay)
+		}
+
+		p = RetryPolicy{Jitter: JitterEqual}
+		got = jitter(p, delay)
+		if got < delay/2 || got > delay {
+			t.Fatalf("JitterEqual: got %v, want in [%v, %v]", got, delay/2, delay)
+		}
+	}
+
+	p := RetryPolicy{Jitter: JitterNone}
+	if got := jitter(p, delay); got != delay {
+		t.Fatalf("JitterNone: got %v, want unchanged %v", got, delay)
+	}
+}
+
+func TestRetryAfterOverridesComputedBackoffOn429(t *testing.T) {
+	var n int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&n, 1)
+		if count == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(429)
+			_, _ = w.Write([]byte("slow down"))
+			return
+		}
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 1
+	p.Retry.BaseDelay = time.Second // would dominate if Retry-After weren't honored
+	p.Retry.MaxDelay = 2 * time.Second
+
+	tr := NewTransport(http.DefaultClient, p)
+
+	start := time.Now()
+	out, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/x", nil, nil)
+	if err != nil {
+		t.Fatalf("expected success, got err: %v", err)
+	}
+	if string(out) != "ok" {
+		t.Fatalf("expected ok, got %q", string(out))
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected Retry-After: 0 to short-circuit the 1s BaseDelay, took %v", elapsed)
+	}
+}
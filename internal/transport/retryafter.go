@@ -0,0 +1,62 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// parseRetryAfter extracts a server-suggested wait duration from the common
+// rate-limit headers, preferring the standard Retry-After (delta-seconds or
+// HTTP-date form) and falling back to X-RateLimit-Reset (epoch seconds or
+// delta-seconds, depending on the exchange). ok is false when none are set
+// or parseable.
+func parseRetryAfter(h http.Header) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if d, ok := parseRetryAfterValue(v); ok {
+			return d, true
+		}
+	}
+	if v := h.Get("X-RateLimit-Reset"); v != "" {
+		if d, ok := parseRetryAfterValue(v); ok {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+func parseRetryAfterValue(v string) (time.Duration, bool) {
+	// delta-seconds, the common case.
+	if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+		// Large integers are almost certainly an epoch timestamp rather than
+		// a delay in seconds (e.g. X-RateLimit-Reset often means "reset at").
+		if secs > 1_000_000_000 {
+			until := time.Unix(secs, 0)
+			if d := time.Until(until); d > 0 {
+				return d, true
+			}
+			return 0, true
+		}
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	// HTTP-date form.
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// rateLimitExhausted reports whether the response signals its bucket is now
+// empty (X-RateLimit-Remaining: 0), so the caller should proactively throttle
+// the next request rather than waiting to be told "no" again.
+func rateLimitExhausted(h http.Header) bool {
+	return h.Get("X-RateLimit-Remaining") == "0"
+}
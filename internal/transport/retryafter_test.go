@@ -0,0 +1,89 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterHeaderDelaysRetryUntilSuccess(t *testing.T) {
+	var n int32
+	var firstAttempt time.Time
+	var secondAttempt time.Time
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := atomic.AddInt32(&n, 1)
+		if count == 1 {
+			firstAttempt = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(429)
+			_, _ = w.Write([]byte(`{"message":"rate limited"}`))
+			return
+		}
+		secondAttempt = time.Now()
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 1
+	p.Retry.BaseDelay = 1 * time.Millisecond
+	p.Retry.MaxDelay = 5 * time.Second
+
+	tr := NewTransport(http.DefaultClient, p)
+
+	out, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/retry-after", nil, nil)
+	if err != nil {
+		t.Fatalf("expected success after honoring Retry-After, got err: %v", err)
+	}
+	if string(out) != "ok" {
+		t.Fatalf("expected ok, got %q", string(out))
+	}
+	if atomic.LoadInt32(&n) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", n)
+	}
+
+	waited := secondAttempt.Sub(firstAttempt)
+	if waited < 900*time.Millisecond {
+		t.Fatalf("expected retry to wait ~1s per Retry-After, only waited %v", waited)
+	}
+}
+
+func TestRateLimitRemainingZeroArmsCooldownForNextCall(t *testing.T) {
+	var n int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n, 1)
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1")
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+
+	tr := NewTransport(http.DefaultClient, p)
+
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/cooldown", nil, nil); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+
+	start := time.Now()
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/cooldown", nil, nil); err != nil {
+		t.Fatalf("second call: %v", err)
+	}
+	waited := time.Since(start)
+
+	if waited < 900*time.Millisecond {
+		t.Fatalf("expected second call to block ~1s on the armed cooldown, only waited %v", waited)
+	}
+	if atomic.LoadInt32(&n) != 2 {
+		t.Fatalf("expected 2 attempts total, got %d", n)
+	}
+}
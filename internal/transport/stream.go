@@ -0,0 +1,183 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+// defaultSpillThreshold is used when Policy.SpillThreshold is unset (<=0):
+// responses up to this size stay in memory, larger ones spill to disk.
+const defaultSpillThreshold = 4 << 20 // 4 MiB
+
+// Response is the result of a DoStream call. Body is fully buffered - either
+// in memory or, once it grows past Policy.SpillThreshold, in a temp file on
+// disk - and seekable either way, so a caller that fails partway through
+// consuming it (e.g. a JSON decode error) can Seek back to the start and
+// replay without re-issuing the HTTP call.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       io.ReadSeekCloser
+}
+
+// DoStream performs req and returns its body as a seekable, spill-aware
+// reader instead of slurping it into a single []byte like DoJSON does. Use
+// this for large paginated results (trade history, full book snapshots)
+// where io.ReadAll-ing the whole response into memory is wasteful.
+//
+// Unlike DoJSON, non-2xx status codes are not turned into a typed error:
+// streaming callers get the raw StatusCode and Body to interpret
+// themselves. Network-level failures while dialing or while copying the
+// body into the spill buffer are retried per Policy.Retry, same as
+// doJSONWithRetry, for idempotent methods only. Policy.MaxBodyBytes is
+// still enforced regardless of Policy.SpillThreshold - a body over the cap
+// fails with types.ErrBodyTooLarge immediately, without retrying, since a
+// retry would just read the same oversized body again.
+func (t *Transport) DoStream(ctx context.Context, req *http.Request) (*Response, error) {
+	p := t.policy.Retry
+	attempts := 0
+
+	if err := ensureReplayableBody(req); err != nil {
+		return nil, types.WithSource(types.KindInternal, err)
+	}
+	var reqBody []byte
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, types.WithSource(types.KindInternal, err)
+		}
+		reqBody, err = io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, types.WithSource(types.KindInternal, err)
+		}
+	}
+
+	for {
+		if err := resetBody(req); err != nil {
+			return nil, types.WithSource(types.KindInternal, err)
+		}
+		if t.policy.Mutate != nil {
+			if err := t.policy.Mutate(req, reqBody); err != nil {
+				return nil, types.WithSource(types.KindInternal, err)
+			}
+		}
+
+		resp, cancel, err := t.dial(ctx, req)
+		if err != nil {
+			if !isIdempotent(req.Method) || attempts >= p.MaxRetries {
+				return nil, types.WithSource(types.KindInternal, err)
+			}
+			attempts++
+			sleepBackoff(ctx, p, attempts, nil)
+			continue
+		}
+
+		body, spillErr := t.spillBody(resp.Body)
+		_ = resp.Body.Close()
+		cancel()
+
+		if spillErr != nil {
+			if errors.Is(spillErr, types.ErrBodyTooLarge) {
+				return nil, types.WithSource(types.KindInternal, spillErr)
+			}
+			if !isIdempotent(req.Method) || attempts >= p.MaxRetries {
+				return nil, types.WithSource(types.KindInternal, spillErr)
+			}
+			attempts++
+			sleepBackoff(ctx, p, attempts, nil)
+			continue
+		}
+
+		return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: body}, nil
+	}
+}
+
+// spillBody reads r to completion, keeping it in memory if it's no larger
+// than Policy.SpillThreshold and otherwise writing it to a temp file. If
+// Policy.MaxBodyBytes is set, it also bounds r up front - the same cap
+// DoJSON enforces via its bodyWrapper - so a response that exceeds it fails
+// with types.ErrBodyTooLarge instead of being spilled to disk unbounded.
+func (t *Transport) spillBody(r io.Reader) (io.ReadSeekCloser, error) {
+	threshold := t.policy.SpillThreshold
+	if threshold <= 0 {
+		threshold = defaultSpillThreshold
+	}
+
+	maxBytes := t.policy.MaxBodyBytes
+	if maxBytes > 0 {
+		r = io.LimitReader(r, maxBytes+1)
+	}
+
+	buf := make([]byte, threshold+1)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	total := int64(n)
+
+	if total <= threshold {
+		if maxBytes > 0 && total > maxBytes {
+			return nil, types.ErrBodyTooLarge
+		}
+		return &memoryBody{Reader: bytes.NewReader(buf[:n])}, nil
+	}
+
+	f, err := os.CreateTemp(t.policy.SpillDir, "poly-clob-spill-*")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(buf[:n]); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	copied, err := io.Copy(f, r)
+	total += copied
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	if maxBytes > 0 && total > maxBytes {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, types.ErrBodyTooLarge
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	return &spillFile{File: f}, nil
+}
+
+// memoryBody backs a small DoStream response: a seekable in-memory buffer
+// with a no-op Close.
+type memoryBody struct {
+	*bytes.Reader
+}
+
+func (m *memoryBody) Close() error { return nil }
+
+// spillFile backs a large DoStream response: the temp file is removed on
+// Close since it's scratch space for the lifetime of one Response, not
+// meant to outlive it.
+type spillFile struct {
+	*os.File
+}
+
+func (s *spillFile) Close() error {
+	name := s.File.Name()
+	err := s.File.Close()
+	if rerr := os.Remove(name); err == nil {
+		err = rerr
+	}
+	return err
+}
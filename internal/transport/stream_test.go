@@ -0,0 +1,286 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+func TestDoStream_SmallBodyStaysInMemory(t *testing.T) {
+	const payload = "small body"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	tr := NewTransport(http.DefaultClient, p)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	resp, err := tr.DoStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DoStream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if _, ok := resp.Body.(*memoryBody); !ok {
+		t.Fatalf("expected *memoryBody, got %T", resp.Body)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(b) != payload {
+		t.Fatalf("expected %q, got %q", payload, string(b))
+	}
+}
+
+func TestDoStream_LargeBodySpillsToDisk(t *testing.T) {
+	payload := strings.Repeat("x", 100)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.SpillThreshold = 10 // force disk spill well below the 100-byte payload
+	tr := NewTransport(http.DefaultClient, p)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	resp, err := tr.DoStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DoStream: %v", err)
+	}
+
+	sf, ok := resp.Body.(*spillFile)
+	if !ok {
+		t.Fatalf("expected *spillFile, got %T", resp.Body)
+	}
+	path := sf.File.Name()
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected spill file to exist at %s: %v", path, err)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(b) != payload {
+		t.Fatalf("body mismatch: got %d bytes, want %d", len(b), len(payload))
+	}
+
+	if err := resp.Body.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected spill file to be removed after Close, stat err: %v", err)
+	}
+}
+
+func TestDoStream_BodyIsSeekableForReplay(t *testing.T) {
+	const payload = "replay me"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	tr := NewTransport(http.DefaultClient, p)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	resp, err := tr.DoStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DoStream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	first, _ := io.ReadAll(resp.Body)
+	if string(first) != payload {
+		t.Fatalf("first read: got %q", string(first))
+	}
+
+	if _, err := resp.Body.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("Seek: %v", err)
+	}
+
+	second, _ := io.ReadAll(resp.Body)
+	if string(second) != payload {
+		t.Fatalf("second read after seek: got %q", string(second))
+	}
+}
+
+func TestDoStream_PassesNon2xxThroughWithoutTypedError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("boom"))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.Breaker = BreakerPolicy{}
+	tr := NewTransport(http.DefaultClient, p)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	resp, err := tr.DoStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", resp.StatusCode)
+	}
+	b, _ := io.ReadAll(resp.Body)
+	if string(b) != "boom" {
+		t.Fatalf("expected body %q, got %q", "boom", string(b))
+	}
+}
+
+func TestDoStream_RetriesNetworkErrorForIdempotentMethod(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			// Simulate a connection drop: close without writing a response.
+			hj, ok := w.(http.Hijacker)
+			if !ok {
+				t.Fatal("ResponseWriter does not support hijacking")
+			}
+			conn, _, err := hj.Hijack()
+			if err != nil {
+				t.Fatalf("hijack: %v", err)
+			}
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 1
+	p.Retry.BaseDelay = 1
+	tr := NewTransport(http.DefaultClient, p)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	resp, err := tr.DoStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DoStream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	b, _ := io.ReadAll(resp.Body)
+	if string(b) != "ok" {
+		t.Fatalf("expected ok after retry, got %q", string(b))
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestDoStream_RejectsBodyOverMaxBodyBytesWithoutRetrying(t *testing.T) {
+	var calls int32
+	payload := strings.Repeat("x", 100)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 2
+	p.MaxBodyBytes = 10 // well below the 100-byte payload
+	tr := NewTransport(http.DefaultClient, p)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	_, err = tr.DoStream(context.Background(), req)
+	if !errors.Is(err, types.ErrBodyTooLarge) {
+		t.Fatalf("expected ErrBodyTooLarge, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected no retries for an oversized body, got %d calls", calls)
+	}
+}
+
+func TestDoStream_SpillsToDiskWithinMaxBodyBytesCap(t *testing.T) {
+	payload := strings.Repeat("x", 100)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer srv.Close()
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.SpillThreshold = 10
+	p.MaxBodyBytes = 1000
+	tr := NewTransport(http.DefaultClient, p)
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/x", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	resp, err := tr.DoStream(context.Background(), req)
+	if err != nil {
+		t.Fatalf("DoStream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(b) != payload {
+		t.Fatalf("body mismatch: got %d bytes, want %d", len(b), len(payload))
+	}
+}
+
+func TestMemoryBody_ImplementsReadSeekCloser(t *testing.T) {
+	var _ io.ReadSeekCloser = &memoryBody{Reader: bytes.NewReader(nil)}
+}
@@ -0,0 +1,111 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+)
+
+// Timings holds the low-level httptrace.ClientTrace phase durations for a
+// single attempt. A phase stays zero when it didn't occur on that attempt
+// (e.g. DNSLookup is zero when the address was already cached, TLSHandshake
+// is zero for a plain-HTTP endpoint).
+type Timings struct {
+	DNSLookup       time.Duration
+	Connect         time.Duration
+	TLSHandshake    time.Duration
+	WroteRequest    time.Duration
+	TimeToFirstByte time.Duration
+}
+
+// Trace lets a caller observe Transport internals - attempt lifecycle,
+// retry decisions, rate-limit waits, and circuit-breaker trips - without
+// Transport depending on any particular tracing/metrics library. Leave it
+// nil (the default) to disable; every callback is optional. See
+// OtelSpanAdapter for a ready-made mapping onto an otelhttp-style span.
+type Trace struct {
+	// OnAttemptStart is called right before each attempt is dialed, with a
+	// 1-based attempt number.
+	OnAttemptStart func(attempt int, req *http.Request)
+	// OnAttemptEnd is called after each attempt completes, successfully or
+	// not, with the httptrace timings captured for it. statusCode is 0 when
+	// the attempt never got a response; a response that arrived but failed
+	// (non-2xx, unreadable or oversized body) reports its status together
+	// with a non-nil err. req is included
+	// (beyond the attempt/status/err/dur an otelhttp-style hook usually
+	// takes) so an adapter can correlate this call back to the span it
+	// opened for the same request in OnAttemptStart.
+	OnAttemptEnd func(req *http.Request, attempt int, statusCode int, err error, dur time.Duration, timings Timings)
+	// OnRetry is called once an attempt has failed and another is about to
+	// be scheduled, before the backoff sleep. reason is a short machine-
+	// readable tag such as "network_error" or "status_429".
+	OnRetry func(reason string, nextDelay time.Duration)
+	// OnRateLimitWait is called whenever dialing actually blocked on a rate
+	// limiter (blanket RateLimiter, EndpointLimiter, or a server-signaled
+	// cooldown) before the request was sent.
+	OnRateLimitWait func(dur time.Duration)
+	// OnCircuitOpen is called when an attempt is short-circuited by an open
+	// breaker instead of being dialed.
+	OnCircuitOpen func(key string)
+}
+
+// withClientTrace attaches an httptrace.ClientTrace to ctx that records
+// DNS/connect/TLS/wroteRequest/gotFirstResponseByte timings into the
+// returned *Timings. Returns ctx unchanged and a nil Timings if tr is nil,
+// so call sites don't need to branch on Trace being configured.
+func withClientTrace(ctx context.Context, tr *Trace) (context.Context, *Timings) {
+	if tr == nil {
+		return ctx, nil
+	}
+
+	timings := &Timings{}
+	start := time.Now()
+	var dnsStart, connectStart, tlsStart time.Time
+
+	ct := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			if !dnsStart.IsZero() {
+				timings.DNSLookup = time.Since(dnsStart)
+			}
+		},
+		ConnectStart: func(network, addr string) {
+			connectStart = time.Now()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			if !connectStart.IsZero() {
+				timings.Connect = time.Since(connectStart)
+			}
+		},
+		TLSHandshakeStart: func() {
+			tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, _ error) {
+			if !tlsStart.IsZero() {
+				timings.TLSHandshake = time.Since(tlsStart)
+			}
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			timings.WroteRequest = time.Since(start)
+		},
+		GotFirstResponseByte: func() {
+			timings.TimeToFirstByte = time.Since(start)
+		},
+	}
+
+	return httptrace.WithClientTrace(ctx, ct), timings
+}
+
+// readTimings dereferences t, returning the zero value for a nil *Timings
+// (i.e. when Trace is disabled) so callers can pass it straight to
+// OnAttemptEnd without a nil check.
+func readTimings(t *Timings) Timings {
+	if t == nil {
+		return Timings{}
+	}
+	return *t
+}
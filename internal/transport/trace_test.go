@@ -0,0 +1,235 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestTrace_AttemptStartAndEndFireOnSuccess(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var starts []int
+	var ends []int
+	var mu sync.Mutex
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.Trace = &Trace{
+		OnAttemptStart: func(attempt int, req *http.Request) {
+			mu.Lock()
+			starts = append(starts, attempt)
+			mu.Unlock()
+		},
+		OnAttemptEnd: func(req *http.Request, attempt, statusCode int, err error, dur time.Duration, timings Timings) {
+			mu.Lock()
+			ends = append(ends, statusCode)
+			mu.Unlock()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if dur <= 0 {
+				t.Fatalf("expected positive attempt duration, got %v", dur)
+			}
+		},
+	}
+	tr := NewTransport(http.DefaultClient, p)
+
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/ping", nil, nil); err != nil {
+		t.Fatalf("DoJSON: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(starts) != 1 || starts[0] != 1 {
+		t.Fatalf("expected OnAttemptStart(1) once, got %v", starts)
+	}
+	if len(ends) != 1 || ends[0] != http.StatusOK {
+		t.Fatalf("expected OnAttemptEnd(200) once, got %v", ends)
+	}
+}
+
+func TestTrace_OnRetryFiresBeforeBackoffOnRetryableStatus(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var reasons []string
+	var mu sync.Mutex
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 1
+	p.Retry.BaseDelay = time.Millisecond
+	p.Retry.Jitter = JitterNone
+	p.Breaker = BreakerPolicy{}
+	p.Trace = &Trace{
+		OnRetry: func(reason string, nextDelay time.Duration) {
+			mu.Lock()
+			reasons = append(reasons, reason)
+			mu.Unlock()
+			if nextDelay <= 0 {
+				t.Fatalf("expected positive nextDelay, got %v", nextDelay)
+			}
+		},
+	}
+	tr := NewTransport(http.DefaultClient, p)
+
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/ping", nil, nil); err != nil {
+		t.Fatalf("DoJSON: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reasons) != 1 || reasons[0] != "status_500" {
+		t.Fatalf("expected a single status_500 retry, got %v", reasons)
+	}
+}
+
+func TestTrace_OnCircuitOpenFiresWhenBreakerIsOpen(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var opens int
+	var mu sync.Mutex
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.Breaker = BreakerPolicy{FailureThreshold: 1, Window: time.Minute, Cooldown: time.Minute}
+	p.Trace = &Trace{
+		OnCircuitOpen: func(key string) {
+			mu.Lock()
+			opens++
+			mu.Unlock()
+		},
+	}
+	tr := NewTransport(http.DefaultClient, p)
+
+	// First call trips the breaker.
+	_, _ = tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/ping", nil, nil)
+	// Second call should be short-circuited without dialing.
+	_, _ = tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/ping", nil, nil)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if opens != 1 {
+		t.Fatalf("expected OnCircuitOpen to fire once, got %d", opens)
+	}
+}
+
+func TestTrace_OnRateLimitWaitFiresWhenLimiterBlocks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var waits []time.Duration
+	var mu sync.Mutex
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.RateLimiter = rate.NewLimiter(rate.Limit(5), 1) // burst of 1, so the 2nd call must wait
+	p.Trace = &Trace{
+		OnRateLimitWait: func(dur time.Duration) {
+			mu.Lock()
+			waits = append(waits, dur)
+			mu.Unlock()
+		},
+	}
+	tr := NewTransport(http.DefaultClient, p)
+
+	for i := 0; i < 2; i++ {
+		if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/ping", nil, nil); err != nil {
+			t.Fatalf("attempt %d: %v", i, err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(waits) != 1 {
+		t.Fatalf("expected exactly one rate-limited wait, got %d (%v)", len(waits), waits)
+	}
+}
+
+type recordingSpan struct {
+	mu    sync.Mutex
+	attrs map[string]any
+	ended bool
+}
+
+func (s *recordingSpan) SetAttribute(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.attrs == nil {
+		s.attrs = map[string]any{}
+	}
+	s.attrs[key] = value
+}
+
+func (s *recordingSpan) End() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ended = true
+}
+
+func TestOtelSpanAdapter_RecordsAttributesAndEndsSpanPerAttempt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var spans []*recordingSpan
+	var mu sync.Mutex
+
+	p := DefaultPolicy()
+	p.Retry.MaxRetries = 0
+	p.Trace = OtelSpanAdapter(func(req *http.Request) Span {
+		s := &recordingSpan{}
+		mu.Lock()
+		spans = append(spans, s)
+		mu.Unlock()
+		return s
+	})
+	tr := NewTransport(http.DefaultClient, p)
+
+	if _, err := tr.DoJSON(context.Background(), http.MethodGet, srv.URL+"/ping", nil, nil); err != nil {
+		t.Fatalf("DoJSON: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(spans) != 1 {
+		t.Fatalf("expected exactly one span, got %d", len(spans))
+	}
+	span := spans[0]
+	span.mu.Lock()
+	defer span.mu.Unlock()
+	if !span.ended {
+		t.Fatal("expected span to be ended")
+	}
+	if span.attrs["http.method"] != http.MethodGet {
+		t.Fatalf("expected http.method=GET, got %v", span.attrs["http.method"])
+	}
+	if span.attrs["http.status_code"] != http.StatusOK {
+		t.Fatalf("expected http.status_code=200, got %v", span.attrs["http.status_code"])
+	}
+	if span.attrs["retry.count"] != 0 {
+		t.Fatalf("expected retry.count=0 for a first attempt, got %v", span.attrs["retry.count"])
+	}
+}
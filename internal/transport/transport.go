@@ -5,9 +5,15 @@ import (
 	"context"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
+
+	"github.com/star-gazer111/poly-go-clob-client/cache"
+	"github.com/star-gazer111/poly-go-clob-client/internal/redaction"
+	"github.com/star-gazer111/poly-go-clob-client/ratelimit"
 )
 
 // these are the rules the transport layer must follow
@@ -17,39 +23,159 @@ type Policy struct {
 	MaxBodyBytes int64
 	Retry        RetryPolicy
 	UserAgent    string
+
+	// Mutate, when set, is invoked on every attempt right before the request
+	// is dialed. It is the injection point for request signing (see
+	// auth.HMACSigner.Mutate): it receives the exact body bytes that will be
+	// sent on the wire (since req.Body may already be a replay reader) and
+	// may set/overwrite headers such as POLY_SIGNATURE in place.
+	Mutate func(req *http.Request, body []byte) error
+
+	// Breaker configures the per-host+endpoint circuit breaker. Leave it
+	// zero-value to disable.
+	Breaker BreakerPolicy
+	// OnBreakerStateChange, when set, is called whenever a breaker entry
+	// transitions state, keyed by the same host+method+path-prefix string
+	// used internally. Useful for logging/metrics.
+	OnBreakerStateChange func(key string, from, to State)
+
+	// EndpointLimiter, when set, paces requests per-endpoint-prefix before
+	// they're dialed (see the ratelimit package), independent of the single
+	// blanket RateLimiter above and of the server-reported 429 handling in
+	// retry.go.
+	EndpointLimiter *ratelimit.Limiter
+
+	// EndpointPicker, when set, enables multi-endpoint failover: each
+	// attempt in doJSONWithRetry's loop dials whichever configured endpoint
+	// EndpointPicker.Pick returns instead of the URL's original host, and
+	// reports the outcome back so flaky backends are demoted instead of
+	// being retried.
+	EndpointPicker EndpointPicker
+
+	// SpillThreshold is the largest response DoStream will buffer in
+	// memory; bodies beyond it are written to a temp file instead. Zero
+	// uses defaultSpillThreshold (4 MiB). Only applies to DoStream, not
+	// DoJSON, which still enforces MaxBodyBytes as a hard cap.
+	SpillThreshold int64
+	// SpillDir is the directory DoStream creates spill files in. Empty
+	// uses os.TempDir.
+	SpillDir string
+
+	// Trace, when set, is notified of attempt lifecycle, retry, rate-limit
+	// wait, and circuit-breaker events for every DoJSON call through this
+	// Transport; DoStream reports only rate-limit waits. Leave it nil (the default) to disable. See OtelSpanAdapter
+	// for a ready-made mapping onto an otelhttp-style span.
+	Trace *Trace
+
+	// Cache, when set, serves DoJSON responses matching CacheRules from the
+	// given cache.Cache instead of dialing, and stores successful JSON
+	// responses back into it. Leave it nil (the default) to disable. See
+	// the cache package and clob.WithCache.
+	Cache      cache.Cache
+	CacheRules []cache.CacheRule
+
+	// RequestIDGenerator overrides how a request ID is minted for a call
+	// whose ctx doesn't already carry one via ContextWithRequestID. Leave
+	// nil to use the built-in ULID-shaped generator.
+	RequestIDGenerator func() string
+
+	// Logger, when set, receives redacted request/response/retry lifecycle
+	// events tagged with each call's request ID. Leave it nil (the
+	// default) to disable.
+	Logger Logger
+
+	// RedactionPolicy controls how headers passed to Logger are redacted.
+	// Nil uses redaction.DefaultPolicy(). Integrators piping logs into a
+	// shared pipeline can supply their own to add vendor-specific header
+	// names/patterns without forking the redaction package.
+	RedactionPolicy *redaction.Policy
 }
 
 func DefaultPolicy() Policy {
 	return Policy{
-		Timeout:      12 * time.Second,
-		RateLimiter:  rate.NewLimiter(rate.Limit(8), 16), // conservative default
-		MaxBodyBytes: 2 << 20,                            // 2 MiB
-		Retry:        DefaultRetryPolicy(),
-		UserAgent:    "poly-go-clob-client/0.1",
+		Timeout:         12 * time.Second,
+		RateLimiter:     rate.NewLimiter(rate.Limit(8), 16), // conservative default
+		MaxBodyBytes:    2 << 20,                            // 2 MiB
+		Retry:           DefaultRetryPolicy(),
+		UserAgent:       "poly-go-clob-client/0.1",
+		Breaker:         DefaultBreakerPolicy(),
+		RedactionPolicy: redaction.DefaultPolicy(),
 	}
 }
 
 type Transport struct {
-	hc     *http.Client
-	policy Policy
+	hc       *http.Client
+	policy   Policy
+	rl       *rateLimitCoolDown
+	breakers *breakerRegistry
 }
 
 func NewTransport(hc *http.Client, p Policy) *Transport {
 	// Ensure hc has no zero timeouts (we enforce via context timeout anyway)
-	return &Transport{hc: hc, policy: p}
+	return &Transport{
+		hc:       hc,
+		policy:   p,
+		rl:       &rateLimitCoolDown{},
+		breakers: newBreakerRegistry(p.Breaker, p.OnBreakerStateChange),
+	}
 }
 
 // basically checks whether all policies are followed or not
 func (t *Transport) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	resp, cancel, err := t.dial(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = &bodyWrapper{
+		ReadCloser: resp.Body,
+		cancel:     cancel,
+		reader:     io.LimitReader(resp.Body, t.policy.MaxBodyBytes+1),
+	}
+
+	return resp, nil
+}
+
+// dial applies rate limiting and the request timeout, then performs the
+// actual HTTP round trip, returning the raw (unwrapped) response. Callers
+// own resp.Body and must either wrap it (as Do does, to tie cancel to
+// Close) or drain it and call cancel themselves (as DoStream does, since it
+// fully buffers the body before returning).
+func (t *Transport) dial(ctx context.Context, req *http.Request) (*http.Response, context.CancelFunc, error) {
+	waitStart := time.Now()
+
 	if t.policy.RateLimiter != nil {
 		if err := t.policy.RateLimiter.Wait(ctx); err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+	}
+
+	if t.policy.EndpointLimiter != nil {
+		if err := t.policy.EndpointLimiter.Wait(ctx, req.URL.Path); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// A previous response told us the bucket is empty (X-RateLimit-Remaining:
+	// 0); honor its reset time before even dialing.
+	if t.rl != nil {
+		if wait := t.rl.remaining(); wait > 0 {
+			if !sleepCtx(ctx, wait) {
+				return nil, nil, ctx.Err()
+			}
+		}
+	}
+
+	// Anything past a millisecond is a real wait rather than mutex/scheduler
+	// noise around the checks above.
+	if tr := t.policy.Trace; tr != nil && tr.OnRateLimitWait != nil {
+		if waited := time.Since(waitStart); waited >= time.Millisecond {
+			tr.OnRateLimitWait(waited)
 		}
 	}
 
 	// We cannot use defer cancel() here because it would cancel the context
 	// immediately after headers are received, breaking body reads.
-	// Instead, we wrap the body to cancel on Close().
 	ctx, cancel := context.WithTimeout(ctx, t.policy.Timeout)
 
 	req = req.WithContext(ctx)
@@ -60,16 +186,10 @@ func (t *Transport) Do(ctx context.Context, req *http.Request) (*http.Response,
 	resp, err := t.hc.Do(req)
 	if err != nil {
 		cancel()
-		return nil, err
+		return nil, nil, err
 	}
 
-	resp.Body = &bodyWrapper{
-		ReadCloser: resp.Body,
-		cancel:     cancel,
-		reader:     io.LimitReader(resp.Body, t.policy.MaxBodyBytes+1),
-	}
-
-	return resp, nil
+	return resp, cancel, nil
 }
 
 type bodyWrapper struct {
@@ -88,6 +208,18 @@ func (w *bodyWrapper) Close() error {
 }
 
 func (t *Transport) DoJSON(ctx context.Context, method, url string, headers map[string]string, body []byte) ([]byte, error) {
+	var cacheKey string
+	var cacheTTL time.Duration
+	if t.policy.Cache != nil {
+		if ttl, ok := cache.MatchRules(t.policy.CacheRules, method, requestPath(url)); ok {
+			cacheKey = method + " " + url
+			cacheTTL = ttl
+			if b, hit := t.policy.Cache.Get(cacheKey); hit {
+				return b, nil
+			}
+		}
+	}
+
 	var r io.Reader
 	if body != nil {
 		r = bytes.NewReader(body)
@@ -104,9 +236,139 @@ func (t *Transport) DoJSON(ctx context.Context, method, url string, headers map[
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	return doJSONWithRetry(ctx, t, req)
+	requestID, ok := RequestIDFromContext(ctx)
+	if !ok {
+		gen := t.policy.RequestIDGenerator
+		if gen == nil {
+			gen = newRequestID
+		}
+		requestID = gen()
+	}
+	req.Header.Set("X-Request-Id", requestID)
+
+	b, err := doJSONWithRetry(ctx, t, req, body, requestID)
+	if err == nil && cacheKey != "" {
+		t.policy.Cache.Set(cacheKey, b, cacheTTL)
+	}
+	return b, err
+}
+
+// requestPath extracts the URL path component for matching against
+// cache.CacheRule.PathPattern, ignoring any query string. An unparseable
+// url (DoJSON will fail on it too, moments later) matches nothing.
+func requestPath(rawURL string) string {
+	u, err := neturl.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Path
 }
 
 func (t *Transport) MaxBodyBytes() int64 {
 	return t.policy.MaxBodyBytes
 }
+
+// WithMutate returns a copy of t whose Policy.Mutate hook is set to fn,
+// leaving the underlying *http.Client and the rest of the policy untouched.
+// This is how signed clients (e.g. clob.L2Client) layer request signing on
+// top of a plain PublicClient's transport.
+func (t *Transport) WithMutate(fn func(req *http.Request, body []byte) error) *Transport {
+	p := t.policy
+	p.Mutate = fn
+	return &Transport{hc: t.hc, policy: p, rl: t.rl, breakers: t.breakers}
+}
+
+// WithEndpointLimiter returns a copy of t whose Policy.EndpointLimiter is set
+// to l, leaving the underlying *http.Client and the rest of the policy
+// untouched. This is how clob.WithRateLimiter layers per-endpoint client-side
+// throttling on top of a plain PublicClient's transport.
+func (t *Transport) WithEndpointLimiter(l *ratelimit.Limiter) *Transport {
+	p := t.policy
+	p.EndpointLimiter = l
+	return &Transport{hc: t.hc, policy: p, rl: t.rl, breakers: t.breakers}
+}
+
+// WithEndpointPicker returns a copy of t whose Policy.EndpointPicker is set
+// to picker, leaving the underlying *http.Client and the rest of the policy
+// untouched. This is how clob.WithFailoverEndpoints layers multi-endpoint
+// failover on top of a plain PublicClient's transport.
+func (t *Transport) WithEndpointPicker(picker EndpointPicker) *Transport {
+	p := t.policy
+	p.EndpointPicker = picker
+	return &Transport{hc: t.hc, policy: p, rl: t.rl, breakers: t.breakers}
+}
+
+// WithCache returns a copy of t whose Policy.Cache/CacheRules are set to c
+// and rules, leaving the underlying *http.Client and the rest of the policy
+// untouched. This is how clob.WithCache layers response caching on top of a
+// plain PublicClient's transport.
+func (t *Transport) WithCache(c cache.Cache, rules []cache.CacheRule) *Transport {
+	p := t.policy
+	p.Cache = c
+	p.CacheRules = rules
+	return &Transport{hc: t.hc, policy: p, rl: t.rl, breakers: t.breakers}
+}
+
+// WithRequestIDGenerator returns a copy of t whose Policy.RequestIDGenerator
+// is set to gen, leaving the underlying *http.Client and the rest of the
+// policy untouched.
+func (t *Transport) WithRequestIDGenerator(gen func() string) *Transport {
+	p := t.policy
+	p.RequestIDGenerator = gen
+	return &Transport{hc: t.hc, policy: p, rl: t.rl, breakers: t.breakers}
+}
+
+// WithLogger returns a copy of t whose Policy.Logger is set to l, leaving
+// the underlying *http.Client and the rest of the policy untouched.
+func (t *Transport) WithLogger(l Logger) *Transport {
+	p := t.policy
+	p.Logger = l
+	return &Transport{hc: t.hc, policy: p, rl: t.rl, breakers: t.breakers}
+}
+
+// WithRedactionPolicy returns a copy of t whose Policy.RedactionPolicy is
+// set to rp, leaving the underlying *http.Client and the rest of the policy
+// untouched. This is how clob.WithRedactionPolicy layers custom header/field
+// redaction rules on top of a plain PublicClient's transport.
+func (t *Transport) WithRedactionPolicy(rp *redaction.Policy) *Transport {
+	p := t.policy
+	p.RedactionPolicy = rp
+	return &Transport{hc: t.hc, policy: p, rl: t.rl, breakers: t.breakers}
+}
+
+// rateLimitCoolDown tracks a server-signaled "bucket empty until X" deadline
+// so the next call blocks correctly instead of racing the server and getting
+// another 429.
+type rateLimitCoolDown struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+func (c *rateLimitCoolDown) arm(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	until := time.Now().Add(d)
+	if until.After(c.until) {
+		c.until = until
+	}
+}
+
+func (c *rateLimitCoolDown) remaining() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Until(c.until)
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,112 @@
+// Package ratelimit provides client-side token-bucket throttling for CLOB
+// REST endpoints. It paces outgoing requests per endpoint prefix before they
+// are ever dialed, which is a distinct concern from the transport's existing
+// server-reported 429/Retry-After handling (see internal/transport): this
+// package stops a client from hammering clob.polymarket.com in the first
+// place, rather than reacting after the server pushes back.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/time/rate"
+)
+
+// EndpointLimit configures the token bucket applied to requests whose path
+// starts with Prefix.
+type EndpointLimit struct {
+	Prefix string
+	Limit  rate.Limit
+	Burst  int
+}
+
+// Policy configures one token bucket per endpoint prefix plus a fallback
+// bucket for paths that don't match any configured prefix.
+type Policy struct {
+	Endpoints []EndpointLimit
+	Default   EndpointLimit
+}
+
+// DefaultPolicy returns conservative per-endpoint defaults for the CLOB
+// REST API's busiest read/write paths.
+func DefaultPolicy() Policy {
+	return Policy{
+		Endpoints: []EndpointLimit{
+			{Prefix: "/markets", Limit: rate.Limit(5), Burst: 10},
+			{Prefix: "/book", Limit: rate.Limit(10), Burst: 20},
+			{Prefix: "/price", Limit: rate.Limit(10), Burst: 20},
+			{Prefix: "/order", Limit: rate.Limit(4), Burst: 8},
+			{Prefix: "/trades", Limit: rate.Limit(5), Burst: 10},
+		},
+		Default: EndpointLimit{Limit: rate.Limit(8), Burst: 16},
+	}
+}
+
+// RateLimitError is returned when a request couldn't acquire a token from
+// its endpoint's bucket within the caller's context (the bucket's budget is
+// exhausted and won't refill in time).
+type RateLimitError struct {
+	Endpoint string
+	Err      error
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("ratelimit: endpoint %q: %v", e.Endpoint, e.Err)
+}
+
+func (e *RateLimitError) Unwrap() error { return e.Err }
+
+// Limiter holds one token bucket per configured endpoint prefix plus a
+// fallback bucket. It is safe for concurrent use.
+type Limiter struct {
+	buckets  []compiledBucket
+	fallback *rate.Limiter
+}
+
+type compiledBucket struct {
+	prefix  string
+	limiter *rate.Limiter
+}
+
+// NewLimiter compiles p into a set of token buckets ready for Wait.
+func NewLimiter(p Policy) *Limiter {
+	l := &Limiter{fallback: rate.NewLimiter(p.Default.Limit, burstOrOne(p.Default.Burst))}
+	for _, ep := range p.Endpoints {
+		l.buckets = append(l.buckets, compiledBucket{
+			prefix:  ep.Prefix,
+			limiter: rate.NewLimiter(ep.Limit, burstOrOne(ep.Burst)),
+		})
+	}
+	return l
+}
+
+func burstOrOne(b int) int {
+	if b <= 0 {
+		return 1
+	}
+	return b
+}
+
+// Wait blocks until a token is available for path, honoring ctx's
+// cancellation/deadline. path is matched against the longest configured
+// endpoint prefix; unmatched paths use the fallback bucket.
+func (l *Limiter) Wait(ctx context.Context, path string) error {
+	if err := l.bucketFor(path).Wait(ctx); err != nil {
+		return &RateLimitError{Endpoint: path, Err: err}
+	}
+	return nil
+}
+
+func (l *Limiter) bucketFor(path string) *rate.Limiter {
+	best := -1
+	bucket := l.fallback
+	for _, b := range l.buckets {
+		if len(b.prefix) > best && strings.HasPrefix(path, b.prefix) {
+			best = len(b.prefix)
+			bucket = b.limiter
+		}
+	}
+	return bucket
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestLimiter_RefillsAtConfiguredRate(t *testing.T) {
+	l := NewLimiter(Policy{
+		Endpoints: []EndpointLimit{{Prefix: "/book", Limit: rate.Every(50 * time.Millisecond), Burst: 1}},
+	})
+
+	ctx := context.Background()
+	start := time.Now()
+	if err := l.Wait(ctx, "/book"); err != nil {
+		t.Fatalf("first Wait: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Millisecond {
+		t.Fatalf("first Wait should consume the initial burst instantly, took %v", elapsed)
+	}
+
+	start = time.Now()
+	if err := l.Wait(ctx, "/book"); err != nil {
+		t.Fatalf("second Wait: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("second Wait should block for a refill, only waited %v", elapsed)
+	}
+}
+
+func TestLimiter_MatchesLongestPrefixAndFallsBackToDefault(t *testing.T) {
+	l := NewLimiter(Policy{
+		Endpoints: []EndpointLimit{
+			{Prefix: "/order", Limit: rate.Every(time.Hour), Burst: 1},
+			{Prefix: "/orders/cancel", Limit: rate.Inf, Burst: 1},
+		},
+		Default: EndpointLimit{Limit: rate.Inf, Burst: 1},
+	})
+
+	// /orders/cancel should match the more specific bucket (rate.Inf: never blocks),
+	// not the /order bucket (1 per hour), even though both share a prefix.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "/orders/cancel/123"); err != nil {
+		t.Fatalf("expected the specific /orders/cancel bucket to admit immediately: %v", err)
+	}
+
+	// An unconfigured path falls back to the (also unlimited) default bucket.
+	if err := l.Wait(ctx, "/unconfigured"); err != nil {
+		t.Fatalf("expected fallback bucket to admit immediately: %v", err)
+	}
+}
+
+func TestLimiter_CancellationSurfacesAsRateLimitError(t *testing.T) {
+	l := NewLimiter(Policy{
+		Endpoints: []EndpointLimit{{Prefix: "/order", Limit: rate.Every(time.Hour), Burst: 1}},
+	})
+
+	ctx := context.Background()
+	if err := l.Wait(ctx, "/order"); err != nil {
+		t.Fatalf("first Wait should consume the burst instantly: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := l.Wait(ctx, "/order")
+	if err == nil {
+		t.Fatal("expected an exhausted bucket to surface an error")
+	}
+
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("expected errors.As to find *RateLimitError, got %T: %v", err, err)
+	}
+	if rlErr.Endpoint != "/order" {
+		t.Errorf("Endpoint = %q, want %q", rlErr.Endpoint, "/order")
+	}
+	if rlErr.Err == nil {
+		t.Error("expected RateLimitError to wrap the underlying wait error")
+	}
+}
@@ -0,0 +1,232 @@
+// Package stream provides a callback-based wrapper over wsstream's
+// channel-based Polymarket WebSocket client, modeled on the handler
+// registration style of Alpaca's marketdata/stream client. It owns no
+// connection logic itself: reconnect-with-backoff, ping/pong keepalive and
+// re-subscription on reconnect are all inherited from wsstream.Client.
+package stream
+
+import (
+	"context"
+	"sync"
+
+	"github.com/star-gazer111/poly-go-clob-client/auth"
+	"github.com/star-gazer111/poly-go-clob-client/wsstream"
+)
+
+// Message aliases mirror wsstream's wire types so callers of this package
+// never need to import wsstream directly.
+type (
+	BookMessage           = wsstream.BookUpdate
+	PriceChangeMessage    = wsstream.PriceChange
+	TickSizeChangeMessage = wsstream.TickSizeChange
+	LastTradePriceMessage = wsstream.LastTradePrice
+	TradeMessage          = wsstream.OrderMatched
+)
+
+// OrderMessage is emitted for order lifecycle events on the user channel
+// that aren't fills (see OnTrade for fills): placement and cancellation.
+type OrderMessage struct {
+	Kind      wsstream.EventKind
+	OrderID   string
+	AssetID   string
+	Market    string
+	Side      string
+	Price     string
+	Size      string
+	Reason    string
+	Timestamp string
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithUserCreds sets the API credentials used to authenticate the user channel.
+func WithUserCreds(creds auth.APICreds) ClientOption {
+	return func(c *Client) { c.wsOpts = append(c.wsOpts, wsstream.WithUserCreds(creds)) }
+}
+
+// WithBackoff overrides the reconnect backoff policy (default: wsstream.DefaultBackoffConfig()).
+func WithBackoff(b wsstream.BackoffConfig) ClientOption {
+	return func(c *Client) { c.wsOpts = append(c.wsOpts, wsstream.WithBackoff(b)) }
+}
+
+// WithQueueSize overrides the buffered size of the underlying event/error channels.
+func WithQueueSize(n int) ClientOption {
+	return func(c *Client) { c.wsOpts = append(c.wsOpts, wsstream.WithQueueSize(n)) }
+}
+
+// Client dispatches Polymarket market/user WebSocket events to registered
+// handlers instead of requiring callers to drain a channel themselves.
+type Client struct {
+	ws     *wsstream.Client
+	wsOpts []wsstream.ClientOption
+
+	mu               sync.RWMutex
+	ctx              context.Context
+	onBookUpdate     func(BookMessage)
+	onPriceChange    func(PriceChangeMessage)
+	onTickSizeChange func(TickSizeChangeMessage)
+	onLastTradePrice func(LastTradePriceMessage)
+	onOrder          func(OrderMessage)
+	onTrade          func(TradeMessage)
+
+	done chan struct{}
+}
+
+// NewClient builds a Client for the given market/user WSS endpoints
+// (e.g. "wss://ws-subscriptions-clob.polymarket.com/ws/market" and ".../ws/user").
+// Either URL may be left empty if the caller only needs the other channel.
+func NewClient(marketURL, userURL string, opts ...ClientOption) *Client {
+	c := &Client{ctx: context.Background(), done: make(chan struct{})}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.ws = wsstream.NewClient(marketURL, userURL, c.wsOpts...)
+	return c
+}
+
+// OnBookUpdate registers the handler invoked for full order-book snapshots.
+func (c *Client) OnBookUpdate(fn func(BookMessage)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onBookUpdate = fn
+}
+
+// OnPriceChange registers the handler invoked for incremental book deltas.
+func (c *Client) OnPriceChange(fn func(PriceChangeMessage)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onPriceChange = fn
+}
+
+// OnTickSizeChange registers the handler invoked when a market's tick size changes.
+func (c *Client) OnTickSizeChange(fn func(TickSizeChangeMessage)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onTickSizeChange = fn
+}
+
+// OnLastTradePrice registers the handler invoked whenever a trade prints for an asset.
+func (c *Client) OnLastTradePrice(fn func(LastTradePriceMessage)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onLastTradePrice = fn
+}
+
+// OnOrder registers the handler invoked for order placement/cancellation
+// events on the user channel (see OnTrade for fills).
+func (c *Client) OnOrder(fn func(OrderMessage)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onOrder = fn
+}
+
+// OnTrade registers the handler invoked when one of the caller's orders fills.
+func (c *Client) OnTrade(fn func(TradeMessage)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onTrade = fn
+}
+
+// Connect starts dispatching events to registered handlers in a background
+// goroutine and returns immediately; ctx governs the connection's lifetime
+// and is reused by Subscribe/SubscribeUser to start their own connection loops.
+func (c *Client) Connect(ctx context.Context) error {
+	c.mu.Lock()
+	c.ctx = ctx
+	c.mu.Unlock()
+
+	go c.dispatchLoop(ctx)
+	return nil
+}
+
+func (c *Client) dispatchLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.done:
+			return
+		case ev, ok := <-c.ws.Events():
+			if !ok {
+				return
+			}
+			c.handle(ev)
+		}
+	}
+}
+
+func (c *Client) handle(ev wsstream.Event) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	switch ev.Kind {
+	case wsstream.EventBookUpdate:
+		if c.onBookUpdate != nil && ev.BookUpdate != nil {
+			c.onBookUpdate(*ev.BookUpdate)
+		}
+	case wsstream.EventPriceChange:
+		if c.onPriceChange != nil && ev.PriceChange != nil {
+			c.onPriceChange(*ev.PriceChange)
+		}
+	case wsstream.EventTickSizeChange:
+		if c.onTickSizeChange != nil && ev.TickSizeChange != nil {
+			c.onTickSizeChange(*ev.TickSizeChange)
+		}
+	case wsstream.EventLastTradePrice:
+		if c.onLastTradePrice != nil && ev.LastTradePrice != nil {
+			c.onLastTradePrice(*ev.LastTradePrice)
+		}
+	case wsstream.EventOrderPlaced:
+		if c.onOrder != nil && ev.OrderPlaced != nil {
+			p := ev.OrderPlaced
+			c.onOrder(OrderMessage{
+				Kind: ev.Kind, OrderID: p.OrderID, AssetID: p.AssetID, Market: p.Market,
+				Side: p.Side, Price: p.Price, Size: p.Size, Timestamp: p.Timestamp,
+			})
+		}
+	case wsstream.EventOrderCancelled:
+		if c.onOrder != nil && ev.OrderCancelled != nil {
+			oc := ev.OrderCancelled
+			c.onOrder(OrderMessage{
+				Kind: ev.Kind, OrderID: oc.OrderID, AssetID: oc.AssetID, Market: oc.Market,
+				Reason: oc.Reason, Timestamp: oc.Timestamp,
+			})
+		}
+	case wsstream.EventOrderMatched:
+		if c.onTrade != nil && ev.OrderMatched != nil {
+			c.onTrade(*ev.OrderMatched)
+		}
+	}
+}
+
+// Subscribe adds assetIDs to the market-channel subscription, lazily
+// starting the market connection on first call.
+func (c *Client) Subscribe(assetIDs ...string) error {
+	c.mu.RLock()
+	ctx := c.ctx
+	c.mu.RUnlock()
+	return c.ws.SubscribeMarket(ctx, assetIDs...)
+}
+
+// SubscribeUser adds markets to the authenticated user-channel subscription,
+// lazily starting the user connection on first call.
+func (c *Client) SubscribeUser(markets ...string) error {
+	c.mu.RLock()
+	ctx := c.ctx
+	c.mu.RUnlock()
+	return c.ws.SubscribeUser(ctx, markets...)
+}
+
+// Errors returns the channel on which connection/protocol errors are delivered.
+func (c *Client) Errors() <-chan error { return c.ws.Errors() }
+
+// Close tears down the underlying connection(s) and stops dispatch.
+func (c *Client) Close() error {
+	select {
+	case <-c.done:
+	default:
+		close(c.done)
+	}
+	return c.ws.Close()
+}
@@ -0,0 +1,109 @@
+//go:build integration
+// +build integration
+
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const marketWSURL = "wss://ws-subscriptions-clob.polymarket.com/ws/market"
+
+// gammaMarket mirrors clob.GammaMarket; duplicated here so this package's
+// integration tests don't need to import clob's unexported test helpers.
+type gammaMarket struct {
+	ClobTokenIds string `json:"clobTokenIds"`
+}
+
+func fetchTokenIDFromGamma(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"https://gamma-api.polymarket.com/markets?limit=5&active=true&closed=false", nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("gamma API returned status %d", resp.StatusCode)
+	}
+
+	var markets []gammaMarket
+	if err := json.NewDecoder(resp.Body).Decode(&markets); err != nil {
+		return "", err
+	}
+
+	for _, m := range markets {
+		if m.ClobTokenIds == "" || m.ClobTokenIds == "null" {
+			continue
+		}
+		var tokenIDs []string
+		if err := json.Unmarshal([]byte(m.ClobTokenIds), &tokenIDs); err != nil {
+			continue
+		}
+		if len(tokenIDs) > 0 && tokenIDs[0] != "" {
+			return tokenIDs[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("no valid token_id found in markets")
+}
+
+// TestIntegration_ReceivesBookSnapshotAndPriceChange subscribes to the live
+// market channel for a real token and asserts at least one book snapshot and
+// one price change arrive within 30s.
+func TestIntegration_ReceivesBookSnapshotAndPriceChange(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tokenID, err := fetchTokenIDFromGamma(ctx)
+	if err != nil {
+		t.Fatalf("fetchTokenIDFromGamma: %v", err)
+	}
+
+	c := NewClient(marketWSURL, "")
+	defer c.Close()
+
+	gotBook := make(chan struct{}, 1)
+	gotPrice := make(chan struct{}, 1)
+	c.OnBookUpdate(func(BookMessage) {
+		select {
+		case gotBook <- struct{}{}:
+		default:
+		}
+	})
+	c.OnPriceChange(func(PriceChangeMessage) {
+		select {
+		case gotPrice <- struct{}{}:
+		default:
+		}
+	})
+
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if err := c.Subscribe(tokenID); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	var sawBook, sawPrice bool
+	for !sawBook || !sawPrice {
+		select {
+		case <-gotBook:
+			sawBook = true
+		case <-gotPrice:
+			sawPrice = true
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for book/price_change events (book=%v price=%v)", sawBook, sawPrice)
+		}
+	}
+}
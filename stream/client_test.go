@@ -0,0 +1,131 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/star-gazer111/poly-go-clob-client/auth"
+)
+
+func echoSubscribeServer(t *testing.T, send func(*websocket.Conn)) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+		send(conn)
+
+		time.Sleep(200 * time.Millisecond)
+	}))
+}
+
+func wsURL(srv *httptest.Server) string {
+	return "ws" + srv.URL[len("http"):]
+}
+
+func TestClient_DispatchesBookUpdateToHandler(t *testing.T) {
+	srv := echoSubscribeServer(t, func(c *websocket.Conn) {
+		_ = c.WriteJSON(map[string]any{
+			"event_type": "book",
+			"asset_id":   "123",
+			"market":     "m1",
+		})
+	})
+	defer srv.Close()
+
+	c := NewClient(wsURL(srv), "", WithQueueSize(4))
+
+	var mu sync.Mutex
+	var got *BookMessage
+	done := make(chan struct{})
+	c.OnBookUpdate(func(m BookMessage) {
+		mu.Lock()
+		defer mu.Unlock()
+		if got == nil {
+			got = &m
+			close(done)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if err := c.Subscribe("123"); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for OnBookUpdate to fire")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got == nil || got.AssetID != "123" {
+		t.Fatalf("unexpected message: %+v", got)
+	}
+}
+
+func TestClient_DispatchesOrderCancelledToOnOrder(t *testing.T) {
+	srv := echoSubscribeServer(t, func(c *websocket.Conn) {
+		_ = c.WriteJSON(map[string]any{
+			"event_type": "order_cancelled",
+			"order_id":   "o1",
+			"reason":     "user_cancelled",
+		})
+	})
+	defer srv.Close()
+
+	c := NewClient("", wsURL(srv), WithQueueSize(4),
+		WithUserCreds(auth.APICreds{Key: "k", Secret: "s", Passphrase: "p"}))
+
+	var mu sync.Mutex
+	var got *OrderMessage
+	done := make(chan struct{})
+	c.OnOrder(func(m OrderMessage) {
+		mu.Lock()
+		defer mu.Unlock()
+		if got == nil {
+			got = &m
+			close(done)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := c.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if err := c.SubscribeUser("m1"); err != nil {
+		t.Fatalf("SubscribeUser: %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for OnOrder to fire")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got == nil || got.OrderID != "o1" || got.Reason != "user_cancelled" {
+		t.Fatalf("unexpected message: %+v", got)
+	}
+}
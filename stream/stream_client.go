@@ -0,0 +1,64 @@
+package stream
+
+import (
+	"context"
+
+	"github.com/star-gazer111/poly-go-clob-client/auth"
+	"github.com/star-gazer111/poly-go-clob-client/wsstream"
+)
+
+// BookEvent is delivered on the channel SubscribeMarket returns: one of
+// BookUpdate, PriceChange, TickSizeChange, or LastTradePrice, matching the
+// market channel's message kinds (see Event.Kind).
+type BookEvent = wsstream.Event
+
+// UserEvent is delivered on the channel SubscribeUser returns: an OrderEvent
+// (OrderPlaced/OrderCancelled) or a TradeEvent (OrderMatched), matching the
+// user channel's message kinds (see Event.Kind).
+type UserEvent = wsstream.Event
+
+// StreamClient is a lower-level counterpart to Client: instead of callback
+// registration, each Subscribe* call returns a channel of typed events, for
+// callers that prefer to range over events directly. It owns no connection
+// logic itself - reconnect-with-backoff, ping/pong keepalive, and
+// re-subscription on reconnect are all inherited from wsstream.Client.
+type StreamClient struct {
+	ws *wsstream.Client
+}
+
+// NewStreamClient builds a StreamClient for the given market/user WSS
+// endpoints (e.g. "wss://ws-subscriptions-clob.polymarket.com/ws/market" and
+// ".../ws/user"). Either URL may be left empty if the caller only needs the
+// other channel.
+func NewStreamClient(marketURL, userURL string, opts ...wsstream.ClientOption) *StreamClient {
+	return &StreamClient{ws: wsstream.NewClient(marketURL, userURL, opts...)}
+}
+
+// SubscribeMarket adds tokenIDs to the market-channel subscription, lazily
+// starting the market connection on first call, and returns the channel on
+// which every BookEvent for every asset subscribed so far (not just
+// tokenIDs from this one call) is delivered.
+func (c *StreamClient) SubscribeMarket(ctx context.Context, tokenIDs []string) (<-chan BookEvent, error) {
+	if err := c.ws.SubscribeMarket(ctx, tokenIDs...); err != nil {
+		return nil, err
+	}
+	return c.ws.Events(), nil
+}
+
+// SubscribeUser authenticates with creds, adds markets to the user-channel
+// subscription, lazily starting the user connection on first call, and
+// returns the channel on which every UserEvent is delivered.
+func (c *StreamClient) SubscribeUser(ctx context.Context, creds auth.APICreds, markets []string) (<-chan UserEvent, error) {
+	c.ws.SetUserCreds(creds)
+	if err := c.ws.SubscribeUser(ctx, markets...); err != nil {
+		return nil, err
+	}
+	return c.ws.Events(), nil
+}
+
+// Errors returns the channel on which connection/protocol errors are
+// delivered, wrapping types.Error the same way REST calls do.
+func (c *StreamClient) Errors() <-chan error { return c.ws.Errors() }
+
+// Close tears down the underlying connection(s).
+func (c *StreamClient) Close() error { return c.ws.Close() }
@@ -0,0 +1,83 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/star-gazer111/poly-go-clob-client/auth"
+	"github.com/star-gazer111/poly-go-clob-client/wsstream"
+)
+
+func TestStreamClient_SubscribeMarketDeliversBookEvent(t *testing.T) {
+	srv := echoSubscribeServer(t, func(c *websocket.Conn) {
+		_ = c.WriteJSON(map[string]any{
+			"event_type": "book",
+			"asset_id":   "123",
+			"market":     "m1",
+		})
+	})
+	defer srv.Close()
+
+	sc := NewStreamClient(wsURL(srv), "", wsstream.WithQueueSize(4))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	events, err := sc.SubscribeMarket(ctx, []string{"123"})
+	if err != nil {
+		t.Fatalf("SubscribeMarket: %v", err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Kind != wsstream.EventBookUpdate || ev.BookUpdate == nil || ev.BookUpdate.AssetID != "123" {
+			t.Fatalf("unexpected event: %+v", ev)
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for a BookEvent")
+	}
+}
+
+func TestStreamClient_SubscribeUserDeliversUserEvent(t *testing.T) {
+	srv := echoSubscribeServer(t, func(c *websocket.Conn) {
+		_ = c.WriteJSON(map[string]any{
+			"event_type": "order_cancelled",
+			"order_id":   "o1",
+			"reason":     "user_cancelled",
+		})
+	})
+	defer srv.Close()
+
+	sc := NewStreamClient("", wsURL(srv), wsstream.WithQueueSize(4))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	creds := auth.APICreds{Key: "k", Secret: "s", Passphrase: "p"}
+	events, err := sc.SubscribeUser(ctx, creds, []string{"m1"})
+	if err != nil {
+		t.Fatalf("SubscribeUser: %v", err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Kind != wsstream.EventOrderCancelled || ev.OrderCancelled == nil || ev.OrderCancelled.OrderID != "o1" {
+			t.Fatalf("unexpected event: %+v", ev)
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for a UserEvent")
+	}
+}
+
+func TestStreamClient_SubscribeUserAcceptsCredsViaSetUserCreds(t *testing.T) {
+	sc := NewStreamClient("", "ws://example.invalid", wsstream.WithQueueSize(4))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if _, err := sc.SubscribeUser(ctx, auth.APICreds{Key: "k"}, []string{"m1"}); err != nil {
+		t.Fatalf("SubscribeUser with creds should pass validation, got: %v", err)
+	}
+}
@@ -78,6 +78,14 @@ func TestClassify(t *testing.T) {
 			wantCode:     "",
 			wantMsg:      "service unavailable",
 		},
+		{
+			name:         "bad request 400 with post-only code routes to code sentinel",
+			status:       400,
+			body:         []byte(`{"message": "would cross the book", "code": "POST_ONLY_WOULD_MATCH"}`),
+			wantSentinel: types.ErrPostOnlyReject,
+			wantCode:     string(types.ErrorCodePostOnlyWouldMatch),
+			wantMsg:      "would cross the book",
+		},
 		{
 			name:         "malformed JSON falls back to bad request",
 			status:       418,
@@ -96,43 +104,43 @@ func TestClassify(t *testing.T) {
 		},
 	}
 
-	for _,tt := range tests{
-		t.Run(tt.name, func(t *testing.T){
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
 			err := types.Classify(tt.status, tt.body)
 
-			if tt.wantNil{
-				if err != nil{
+			if tt.wantNil {
+				if err != nil {
 					t.Errorf("expected nil error for success status, got %v", err)
 				}
 				return
 			}
 
-			if err == nil{
+			if err == nil {
 				t.Fatal("expected error, got nil")
 			}
 
-			if !errors.Is(err, tt.wantSentinel){
+			if !errors.Is(err, tt.wantSentinel) {
 				t.Errorf("expected sentinel %v, got %v", tt.wantSentinel, err)
 			}
 
 			apiErr, ok := err.(*types.APIError)
-			if !ok{
+			if !ok {
 				t.Fatalf("error is not *types.APIError, got %v", apiErr)
 			}
 
-			if apiErr.Status != tt.status{
+			if apiErr.Status != tt.status {
 				t.Errorf("expected status : %v, got status : %v", tt.status, apiErr.Status)
 			}
 
-			if apiErr.Code != tt.wantCode{
-				t.Errorf("expected status : %v, got status : %v", tt.wantCode, apiErr.Code)
+			if string(apiErr.Code) != tt.wantCode {
+				t.Errorf("expected code : %v, got code : %v", tt.wantCode, apiErr.Code)
 			}
 
-			if apiErr.Message != tt.wantMsg{
-				t.Errorf("expected status : %v, got status : %v", tt.wantMsg, apiErr.Message)
+			if apiErr.Message != tt.wantMsg {
+				t.Errorf("expected message : %v, got message : %v", tt.wantMsg, apiErr.Message)
 			}
 
-			if string(apiErr.RawBody) != string(tt.body){
+			if string(apiErr.RawBody) != string(tt.body) {
 				t.Errorf("RawBody is not preserved correctly")
 			}
 
@@ -0,0 +1,157 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrorCode is a documented CLOB business error code, as returned in the
+// "code" field of the JSON error envelope (see HTTPErrorBody).
+type ErrorCode string
+
+const (
+	ErrorCodeInsufficientBalance     ErrorCode = "INSUFFICIENT_BALANCE"
+	ErrorCodeInvalidOrder            ErrorCode = "INVALID_ORDER"
+	ErrorCodeMarketClosed            ErrorCode = "MARKET_CLOSED"
+	ErrorCodeNotEnoughTakerLiquidity ErrorCode = "NOT_ENOUGH_TAKER_LIQUIDITY"
+	ErrorCodeFOKOrderNotFillable     ErrorCode = "FOK_ORDER_NOT_FILLABLE"
+	ErrorCodePostOnlyWouldMatch      ErrorCode = "POST_ONLY_WOULD_MATCH"
+	ErrorCodeTickSizeMismatch        ErrorCode = "TICK_SIZE_MISMATCH"
+	ErrorCodeMinOrderSize            ErrorCode = "MIN_ORDER_SIZE"
+)
+
+// -------- Status-class sentinels (errors.Is targets for HTTP status buckets) --------
+
+var (
+	ErrRateLimited  = errors.New("rate limited")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrBadRequest   = errors.New("bad request")
+	ErrServer       = errors.New("server error")
+)
+
+// -------- Per-code sentinels (errors.Is targets for specific business errors) --------
+
+var (
+	ErrInsufficientBalance     = errors.New("insufficient balance")
+	ErrInvalidOrder            = errors.New("invalid order")
+	ErrMarketClosed            = errors.New("market closed")
+	ErrNotEnoughTakerLiquidity = errors.New("not enough taker liquidity")
+	ErrFOKOrderNotFillable     = errors.New("fill-or-kill order is not fillable")
+	ErrPostOnlyReject          = errors.New("post-only order would match and was rejected")
+	ErrTickSizeMismatch        = errors.New("tick size mismatch")
+	ErrMinOrderSize            = errors.New("order size is below the minimum")
+)
+
+// codeSentinels maps a documented ErrorCode to the sentinel errors.Is callers
+// should match against, so business logic can branch without string matching.
+var codeSentinels = map[ErrorCode]error{
+	ErrorCodeInsufficientBalance:     ErrInsufficientBalance,
+	ErrorCodeInvalidOrder:            ErrInvalidOrder,
+	ErrorCodeMarketClosed:            ErrMarketClosed,
+	ErrorCodeNotEnoughTakerLiquidity: ErrNotEnoughTakerLiquidity,
+	ErrorCodeFOKOrderNotFillable:     ErrFOKOrderNotFillable,
+	ErrorCodePostOnlyWouldMatch:      ErrPostOnlyReject,
+	ErrorCodeTickSizeMismatch:        ErrTickSizeMismatch,
+	ErrorCodeMinOrderSize:            ErrMinOrderSize,
+}
+
+// APIError is a classified non-2xx CLOB API response. It carries both the
+// raw HTTP status bucket (via errors.Is against ErrRateLimited/ErrUnauthorized/
+// ErrBadRequest/ErrServer) and, when the server included a documented "code",
+// the more specific business sentinel (e.g. ErrPostOnlyReject).
+type APIError struct {
+	Status  int
+	Code    ErrorCode
+	Message string
+	RawBody []byte
+
+	statusSentinel error
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("api error %d [%s]: %s", e.Status, e.Code, e.Message)
+	}
+	return fmt.Sprintf("api error %d: %s", e.Status, e.Message)
+}
+
+// Is lets errors.Is(err, types.ErrBadRequest) and errors.Is(err, types.ErrPostOnlyReject)
+// both work against the same *APIError without either sentinel being its src/cause.
+func (e *APIError) Is(target error) bool {
+	if e.statusSentinel != nil && target == e.statusSentinel {
+		return true
+	}
+	if cs, ok := codeSentinels[e.Code]; ok && target == cs {
+		return true
+	}
+	return false
+}
+
+// statusSentinelFor buckets a raw HTTP status into the coarse sentinel set.
+func statusSentinelFor(status int) error {
+	switch {
+	case status == 429:
+		return ErrRateLimited
+	case status == 401 || status == 403:
+		return ErrUnauthorized
+	case status >= 500:
+		return ErrServer
+	default:
+		return ErrBadRequest
+	}
+}
+
+// Classify turns an HTTP status + raw JSON body into a structured *APIError,
+// or nil for 2xx responses. It is the typed-error-code counterpart to
+// ClassifyHTTP, which instead wraps the result as a *types.Error/KindStatus
+// pair for the transport layer.
+func Classify(status int, body []byte) error {
+	if status >= 200 && status <= 299 {
+		return nil
+	}
+
+	var parsed HTTPErrorBody
+	_ = json.Unmarshal(body, &parsed)
+
+	msg := parsed.Message
+	if msg == "" {
+		msg = parsed.Error
+	}
+
+	var code ErrorCode
+	if s, ok := parsed.Code.(string); ok {
+		code = ErrorCode(s)
+	}
+
+	return &APIError{
+		Status:         status,
+		Code:           code,
+		Message:        msg,
+		RawBody:        body,
+		statusSentinel: statusSentinelFor(status),
+	}
+}
+
+// IsCode reports whether err is (or wraps) business code c, whether that's a
+// bare *APIError from a direct Classify call or a *Status produced by the
+// transport's retry path (see Status.Code).
+func IsCode(err error, c ErrorCode) bool {
+	if ae, ok := AsAPIError(err); ok {
+		return ae.Code == c
+	}
+	var s *Status
+	if errors.As(err, &s) {
+		return s.Code == c
+	}
+	return false
+}
+
+// AsAPIError unwraps err into a *APIError, if present anywhere in its chain.
+func AsAPIError(err error) (*APIError, bool) {
+	var ae *APIError
+	if errors.As(err, &ae) {
+		return ae, true
+	}
+	return nil, false
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"runtime/debug"
 	"strings"
+	"time"
 )
 
 // kind matches the Rust enum Kind
@@ -13,6 +14,11 @@ type Kind string
 
 var ErrBodyTooLarge = errors.New("response body too large")
 
+// ErrEndpointCircuitOpen is reported to a transport.EndpointPicker when an
+// endpoint is skipped because its own circuit breaker is already open,
+// letting multi-endpoint failover demote it without dialing.
+var ErrEndpointCircuitOpen = errors.New("endpoint circuit open")
+
 const (
 	KindStatus          Kind = "status"
 	KindValidation      Kind = "validation"
@@ -20,13 +26,15 @@ const (
 	KindInternal        Kind = "internal"
 	KindWebSocket       Kind = "websocket"
 	KindGeoblock        Kind = "geoblock"
+	KindCircuitOpen     Kind = "circuit_open"
 )
 
 // error is the top-level error wrapper
 type Error struct {
-	kind  Kind
-	src   error
-	stack []byte // lightweight equivalent of backtrace
+	kind      Kind
+	src       error
+	stack     []byte // lightweight equivalent of backtrace
+	requestID string
 }
 
 func (e *Error) Error() string {
@@ -46,6 +54,20 @@ func (e *Error) Kind() Kind { return e.kind }
 // stack returns a captured stack trace (if enabled)
 func (e *Error) Stack() []byte { return e.stack }
 
+// RequestID returns the X-Request-Id of the call that produced this error,
+// or "" if it wasn't set (e.g. errors constructed outside of transport's
+// request/response path, such as ValidationErr). See WithRequestID.
+func (e *Error) RequestID() string { return e.requestID }
+
+// WithRequestID returns a copy of e tagged with id, leaving e itself
+// unchanged. transport.DoJSON uses this to attach the request's
+// X-Request-Id to the Status error it returns on non-2xx responses.
+func (e *Error) WithRequestID(id string) *Error {
+	cp := *e
+	cp.requestID = id
+	return &cp
+}
+
 // WithSource mirrors Rust's Error::with_source
 func WithSource(kind Kind, src error) *Error {
 	if src == nil {
@@ -61,6 +83,30 @@ type Status struct {
 	Method     string
 	Path       string
 	Message    string
+	// RetryAfter is the server-suggested wait before retrying, parsed from a
+	// Retry-After / X-RateLimit-Reset response header. Zero if the server
+	// didn't provide one.
+	RetryAfter time.Duration
+	// Code is the documented business error code (see ErrorCode) parsed from
+	// the response body's "code" field, if the server included one. Empty
+	// when the server didn't send a documented code. doJSONWithRetry
+	// populates this via Classify so callers can branch on it without
+	// re-parsing the body themselves.
+	Code ErrorCode
+}
+
+// Is lets errors.Is(err, ErrBadRequest) and errors.Is(err, ErrPostOnlyReject)
+// match a transport-produced Status the same way they'd match a bare
+// *APIError, since doJSONWithRetry folds Classify's result into Status.Code
+// rather than returning *APIError directly. See Code's doc comment.
+func (s *Status) Is(target error) bool {
+	if target == statusSentinelFor(s.StatusCode) {
+		return true
+	}
+	if cs, ok := codeSentinels[s.Code]; ok && target == cs {
+		return true
+	}
+	return false
 }
 
 func (s *Status) Error() string {
@@ -141,6 +187,38 @@ func GeoblockErr(ip, country, region string) *Error {
 	})
 }
 
+// CircuitOpen is returned when the transport's circuit breaker has tripped
+// for a given host+endpoint key and is short-circuiting calls without
+// hitting the round-tripper.
+type CircuitOpen struct {
+	Key        string
+	RetryAfter time.Duration
+	LastStatus *Status
+}
+
+func (c *CircuitOpen) Error() string {
+	if c.LastStatus != nil {
+		return fmt.Sprintf("circuit open for %s, retry after %s: %s", c.Key, c.RetryAfter, c.LastStatus.Error())
+	}
+	return fmt.Sprintf("circuit open for %s, retry after %s", c.Key, c.RetryAfter)
+}
+
+func CircuitOpenErr(key string, retryAfter time.Duration, lastStatus *Status) *Error {
+	return WithSource(KindCircuitOpen, &CircuitOpen{
+		Key:        key,
+		RetryAfter: retryAfter,
+		LastStatus: lastStatus,
+	})
+}
+
+func AsCircuitOpen(err error) (*CircuitOpen, bool) {
+	var c *CircuitOpen
+	if errors.As(err, &c) {
+		return c, true
+	}
+	return nil, false
+}
+
 func AsStatus(err error) (*Status, bool) {
 	var s *Status
 	if errors.As(err, &s) {
@@ -162,3 +162,18 @@ func TestClassifyHTTPProducesStatusErr(t *testing.T) {
 		t.Fatalf("expected 403, got %d", st.StatusCode)
 	}
 }
+
+func TestError_WithRequestIDDoesNotMutateOriginal(t *testing.T) {
+	base := StatusErr(500, http.MethodGet, "/ping", "boom")
+	if base.RequestID() != "" {
+		t.Fatalf("expected empty RequestID by default, got %q", base.RequestID())
+	}
+
+	tagged := base.WithRequestID("01ABC")
+	if tagged.RequestID() != "01ABC" {
+		t.Fatalf("expected RequestID 01ABC, got %q", tagged.RequestID())
+	}
+	if base.RequestID() != "" {
+		t.Fatalf("expected WithRequestID to leave the original untouched, got %q", base.RequestID())
+	}
+}
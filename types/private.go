@@ -0,0 +1,66 @@
+package types
+
+// OpenOrder represents a resting order owned by the authenticated API key.
+type OpenOrder struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	Market       string `json:"market"`
+	AssetID      string `json:"asset_id"`
+	Side         string `json:"side"`
+	Price        string `json:"price"`
+	OriginalSize string `json:"original_size"`
+	SizeMatched  string `json:"size_matched"`
+	Owner        string `json:"owner"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+// Trade represents a single fill from the authenticated account's trade history.
+type Trade struct {
+	ID           string `json:"id"`
+	TakerOrderID string `json:"taker_order_id"`
+	Market       string `json:"market"`
+	AssetID      string `json:"asset_id"`
+	Side         string `json:"side"`
+	Size         string `json:"size"`
+	Price        string `json:"price"`
+	Status       string `json:"status"`
+	MatchTime    string `json:"match_time"`
+}
+
+// ApiKeyEntry is a single API key registered to the authenticated address.
+type ApiKeyEntry struct {
+	APIKey string `json:"apiKey"`
+}
+
+// OrderResponse is returned after submitting an order for matching.
+type OrderResponse struct {
+	Success         bool   `json:"success"`
+	ErrorMsg        string `json:"errorMsg,omitempty"`
+	OrderID         string `json:"orderID,omitempty"`
+	TransactionHash string `json:"transactionsHash,omitempty"`
+	Status          string `json:"status,omitempty"`
+}
+
+// CancelOrdersRequest cancels one or more orders by ID.
+type CancelOrdersRequest struct {
+	OrderIDs []string `json:"orderIDs"`
+}
+
+// CancelResponse reports which orders were cancelled and which were not (with a reason).
+type CancelResponse struct {
+	Canceled    []string          `json:"canceled"`
+	NotCanceled map[string]string `json:"not_canceled,omitempty"`
+}
+
+// GetOpenOrdersRequest filters the open-orders listing. Zero-value fields are omitted.
+type GetOpenOrdersRequest struct {
+	Market  string `json:"market,omitempty"`
+	AssetID string `json:"asset_id,omitempty"`
+}
+
+// GetTradeHistoryRequest filters the trade-history listing. Zero-value fields are omitted.
+type GetTradeHistoryRequest struct {
+	Market     string `json:"market,omitempty"`
+	AssetID    string `json:"asset_id,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
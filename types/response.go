@@ -115,3 +115,29 @@ type OrderBookSummaryResponse struct {
 	TickSize       float64        `json:"tick_size"`
 	LastTradePrice *float64       `json:"last_trade_price,omitempty"`
 }
+
+// MidpointResponse represents the response from the midpoint endpoint.
+type MidpointResponse struct {
+	Mid decimal.Decimal `json:"mid"`
+}
+
+// PriceResponse represents the response from the price endpoint.
+type PriceResponse struct {
+	Price decimal.Decimal `json:"price"`
+}
+
+// SpreadResponse represents the response from the spread endpoint.
+type SpreadResponse struct {
+	Spread decimal.Decimal `json:"spread"`
+}
+
+// PriceHistoryItem is a single (time, price) sample in a PricesHistoryResponse.
+type PriceHistoryItem struct {
+	Time  int64           `json:"t"`
+	Price decimal.Decimal `json:"p"`
+}
+
+// PricesHistoryResponse represents the response from the prices-history endpoint.
+type PricesHistoryResponse struct {
+	History []PriceHistoryItem `json:"history"`
+}
@@ -0,0 +1,54 @@
+package wsstream
+
+import (
+	"math/rand"
+	"time"
+)
+
+// BackoffConfig controls the reconnect delay between dial attempts.
+type BackoffConfig struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter float64 // fraction of the delay to randomize, e.g. 0.2 = +/-20%
+}
+
+func DefaultBackoffConfig() BackoffConfig {
+	return BackoffConfig{
+		Base:   250 * time.Millisecond,
+		Max:    30 * time.Second,
+		Jitter: 0.2,
+	}
+}
+
+// delay returns the backoff duration for the given attempt (1-indexed).
+func (b BackoffConfig) delay(attempt int) time.Duration {
+	base := b.Base
+	if base <= 0 {
+		base = 250 * time.Millisecond
+	}
+	max := b.Max
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+
+	d := base
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= max {
+			d = max
+			break
+		}
+	}
+	if d > max {
+		d = max
+	}
+
+	if b.Jitter > 0 {
+		spread := float64(d) * b.Jitter
+		d = time.Duration(float64(d) - spread + rand.Float64()*2*spread)
+		if d < 0 {
+			d = 0
+		}
+	}
+	return d
+}
@@ -0,0 +1,444 @@
+package wsstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/star-gazer111/poly-go-clob-client/auth"
+	"github.com/star-gazer111/poly-go-clob-client/types"
+)
+
+// ErrQueueOverflow is delivered on Client.Errors() when a slow consumer isn't
+// draining Client.Events() fast enough and an event had to be dropped.
+var ErrQueueOverflow = errors.New("wsstream: listener queue overflow, event dropped")
+
+// ClientOption configures a Client.
+type ClientOption func(*Client)
+
+// WithBackoff overrides the reconnect backoff policy (default: DefaultBackoffConfig()).
+func WithBackoff(b BackoffConfig) ClientOption {
+	return func(c *Client) { c.backoff = b }
+}
+
+// WithQueueSize overrides the buffered size of the Events()/Errors() channels (default: 256).
+func WithQueueSize(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.queueSize = n
+		}
+	}
+}
+
+// WithUserCreds sets the API credentials used to authenticate the user channel.
+func WithUserCreds(creds auth.APICreds) ClientOption {
+	return func(c *Client) { c.creds = &creds }
+}
+
+// Client consumes Polymarket's market and user WebSocket channels and
+// delivers strongly-typed events on Go channels, reconnecting automatically.
+type Client struct {
+	marketURL string
+	userURL   string
+	creds     *auth.APICreds
+	backoff   BackoffConfig
+	queueSize int
+
+	events chan Event
+	errs   chan error
+
+	mu           sync.Mutex
+	marketAssets map[string]struct{}
+	userMarkets  map[string]struct{}
+	marketConn   *connection
+	userConn     *connection
+
+	lastSeq map[string]uint64
+
+	startOnce       sync.Once
+	userLoopStarted bool
+}
+
+// NewClient builds a Client for the given market/user WSS endpoints
+// (e.g. "wss://ws-subscriptions-clob.polymarket.com/ws/market" and ".../ws/user").
+// Either URL may be left empty if the caller only needs the other channel.
+func NewClient(marketURL, userURL string, opts ...ClientOption) *Client {
+	c := &Client{
+		marketURL:    marketURL,
+		userURL:      userURL,
+		backoff:      DefaultBackoffConfig(),
+		queueSize:    256,
+		marketAssets: make(map[string]struct{}),
+		userMarkets:  make(map[string]struct{}),
+		lastSeq:      make(map[string]uint64),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.events = make(chan Event, c.queueSize)
+	c.errs = make(chan error, c.queueSize)
+	return c
+}
+
+// Events returns the channel on which typed events are delivered.
+func (c *Client) Events() <-chan Event { return c.events }
+
+// Errors returns the channel on which connection/protocol errors are delivered.
+// Errors wrap types.Error so callers can errors.As for *types.Status (handshake
+// failures) or check Kind() == types.KindWebSocket / types.KindSynchronization.
+func (c *Client) Errors() <-chan error { return c.errs }
+
+// SubscribeMarket adds assetIDs to the market-channel subscription set,
+// (re)sending the subscribe frame immediately if connected, and lazily starts
+// the market connection loop on first call.
+func (c *Client) SubscribeMarket(ctx context.Context, assetIDs ...string) error {
+	if c.marketURL == "" {
+		return types.ValidationErr("wsstream: no market URL configured")
+	}
+	c.mu.Lock()
+	for _, id := range assetIDs {
+		c.marketAssets[id] = struct{}{}
+	}
+	conn := c.marketConn
+	c.mu.Unlock()
+
+	if conn != nil {
+		if err := conn.writeJSON(c.marketSubscribeMsg()); err != nil {
+			return types.WithSource(types.KindWebSocket, err)
+		}
+	}
+
+	c.startOnce.Do(func() { go c.runLoop(ctx, topicMarket) })
+	return nil
+}
+
+// SetUserCreds sets (or overrides) the credentials used to authenticate the
+// user channel, equivalent to passing WithUserCreds at construction time.
+// Safe to call before the first SubscribeUser; if the user connection is
+// already running, the new credentials take effect on the next (re)connect.
+func (c *Client) SetUserCreds(creds auth.APICreds) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.creds = &creds
+}
+
+// SubscribeUser adds markets to the user-channel subscription set and lazily
+// starts the authenticated user connection loop on first call.
+func (c *Client) SubscribeUser(ctx context.Context, markets ...string) error {
+	if c.userURL == "" {
+		return types.ValidationErr("wsstream: no user URL configured")
+	}
+	if c.creds == nil {
+		return types.ValidationErr("wsstream: user channel requires WithUserCreds")
+	}
+	c.mu.Lock()
+	for _, m := range markets {
+		c.userMarkets[m] = struct{}{}
+	}
+	conn := c.userConn
+	c.mu.Unlock()
+
+	if conn != nil {
+		if err := conn.writeJSON(c.userSubscribeMsg()); err != nil {
+			return types.WithSource(types.KindWebSocket, err)
+		}
+	}
+
+	c.startUserLoop(ctx)
+	return nil
+}
+
+type topic int
+
+const (
+	topicMarket topic = iota
+	topicUser
+)
+
+func (c *Client) startUserLoop(ctx context.Context) {
+	c.mu.Lock()
+	already := c.userLoopStarted
+	c.userLoopStarted = true
+	c.mu.Unlock()
+	if !already {
+		go c.runLoop(ctx, topicUser)
+	}
+}
+
+func (c *Client) marketSubscribeMsg() subscribeMarketMsg {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]string, 0, len(c.marketAssets))
+	for id := range c.marketAssets {
+		ids = append(ids, id)
+	}
+	return subscribeMarketMsg{Type: "market", AssetsIDs: ids}
+}
+
+func (c *Client) userSubscribeMsg() subscribeUserMsg {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	markets := make([]string, 0, len(c.userMarkets))
+	for m := range c.userMarkets {
+		markets = append(markets, m)
+	}
+	msg := subscribeUserMsg{Type: "user", Markets: markets}
+	if c.creds != nil {
+		msg.Auth = &authPayload{APIKey: c.creds.Key, Secret: c.creds.Secret, Passphrase: c.creds.Passphrase}
+	}
+	return msg
+}
+
+type subscribeMarketMsg struct {
+	Type      string   `json:"type"`
+	AssetsIDs []string `json:"assets_ids"`
+}
+
+type subscribeUserMsg struct {
+	Type    string       `json:"type"`
+	Markets []string     `json:"markets"`
+	Auth    *authPayload `json:"auth,omitempty"`
+}
+
+type authPayload struct {
+	APIKey     string `json:"apiKey"`
+	Secret     string `json:"secret"`
+	Passphrase string `json:"passphrase"`
+}
+
+// runLoop owns the reconnect-with-backoff lifecycle for a single topic.
+func (c *Client) runLoop(ctx context.Context, t topic) {
+	attempt := 0
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		url := c.marketURL
+		if t == topicUser {
+			url = c.userURL
+		}
+
+		conn, resp, err := dial(ctx, url, http.Header{})
+		if err != nil {
+			attempt++
+			if resp != nil {
+				c.emitErr(types.StatusErr(resp.StatusCode, http.MethodGet, url, err.Error()))
+			} else {
+				c.emitErr(types.WithSource(types.KindWebSocket, err))
+			}
+			if !c.sleep(ctx, attempt) {
+				return
+			}
+			continue
+		}
+		attempt = 0
+
+		c.mu.Lock()
+		if t == topicMarket {
+			c.marketConn = conn
+		} else {
+			c.userConn = conn
+		}
+		c.mu.Unlock()
+
+		// Re-subscribe from the stored subscription set (covers both the
+		// initial connect and every reconnect).
+		var subErr error
+		if t == topicMarket {
+			subErr = conn.writeJSON(c.marketSubscribeMsg())
+		} else {
+			subErr = conn.writeJSON(c.userSubscribeMsg())
+		}
+		if subErr != nil {
+			c.emitErr(types.WithSource(types.KindWebSocket, subErr))
+			conn.close()
+			continue
+		}
+
+		// A fresh subscribe means the server will replay snapshots from
+		// scratch, so any sequence numbers checkSequence remembered from
+		// before this connection no longer mean anything - without this the
+		// first price_change after a reconnect is compared against a stale
+		// pre-disconnect sequence and almost always fires a spurious
+		// SyncErr. Same class of false-positive desync as the one fixed for
+		// book.LocalBook's hash comparison; see book.LocalBook.Desynced.
+		if t == topicMarket {
+			c.resetSeq()
+		}
+
+		c.pumpUntilDisconnect(ctx, conn, t)
+
+		c.mu.Lock()
+		if t == topicMarket {
+			c.marketConn = nil
+		} else {
+			c.userConn = nil
+		}
+		c.mu.Unlock()
+
+		if ctx.Err() != nil {
+			return
+		}
+		attempt++
+		if !c.sleep(ctx, attempt) {
+			return
+		}
+	}
+}
+
+// pumpUntilDisconnect keeps the connection alive with periodic pings and
+// dispatches incoming frames until the socket errors out or ctx is cancelled.
+func (c *Client) pumpUntilDisconnect(ctx context.Context, conn *connection, t topic) {
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		ticker := time.NewTicker(pingEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				conn.close()
+				return
+			case <-ticker.C:
+				if err := conn.ping(); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	for {
+		b, err := conn.readMessage()
+		if err != nil {
+			conn.close()
+			return
+		}
+		c.dispatch(b)
+	}
+}
+
+func (c *Client) sleep(ctx context.Context, attempt int) bool {
+	d := c.backoff.delay(attempt)
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+func (c *Client) dispatch(raw []byte) {
+	var env wireMessage
+	if err := json.Unmarshal(raw, &env); err != nil {
+		c.emitErr(types.WithSource(types.KindWebSocket, err))
+		return
+	}
+
+	switch EventKind(env.EventType) {
+	case EventBookUpdate:
+		var m BookUpdate
+		if err := json.Unmarshal(raw, &m); err == nil {
+			c.emit(Event{Kind: EventBookUpdate, BookUpdate: &m})
+		}
+	case EventPriceChange:
+		var m PriceChange
+		if err := json.Unmarshal(raw, &m); err == nil {
+			c.checkSequence(m.AssetID, m.Seq)
+			c.emit(Event{Kind: EventPriceChange, PriceChange: &m})
+		}
+	case EventTickSizeChange:
+		var m TickSizeChange
+		if err := json.Unmarshal(raw, &m); err == nil {
+			c.emit(Event{Kind: EventTickSizeChange, TickSizeChange: &m})
+		}
+	case EventLastTradePrice:
+		var m LastTradePrice
+		if err := json.Unmarshal(raw, &m); err == nil {
+			c.emit(Event{Kind: EventLastTradePrice, LastTradePrice: &m})
+		}
+	case EventOrderPlaced:
+		var m OrderPlaced
+		if err := json.Unmarshal(raw, &m); err == nil {
+			c.emit(Event{Kind: EventOrderPlaced, OrderPlaced: &m})
+		}
+	case EventOrderMatched:
+		var m OrderMatched
+		if err := json.Unmarshal(raw, &m); err == nil {
+			c.emit(Event{Kind: EventOrderMatched, OrderMatched: &m})
+		}
+	case EventOrderCancelled:
+		var m OrderCancelled
+		if err := json.Unmarshal(raw, &m); err == nil {
+			c.emit(Event{Kind: EventOrderCancelled, OrderCancelled: &m})
+		}
+	}
+}
+
+// checkSequence surfaces a KindSynchronization error when a per-asset
+// sequence gap is detected on the price_change stream.
+func (c *Client) checkSequence(assetID string, seq uint64) {
+	if seq == 0 {
+		return // server did not include a sequence number
+	}
+	c.mu.Lock()
+	last, ok := c.lastSeq[assetID]
+	c.lastSeq[assetID] = seq
+	c.mu.Unlock()
+
+	if ok && seq != last+1 {
+		c.emitErr(types.SyncErr())
+	}
+}
+
+// resetSeq clears the per-asset sequence baseline tracked by checkSequence.
+// Called whenever a fresh market-channel subscribe is established (initial
+// connect or reconnect), since the server starts a new sequence from
+// wherever its next snapshot lands.
+func (c *Client) resetSeq() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastSeq = make(map[string]uint64)
+}
+
+func (c *Client) emit(e Event) {
+	select {
+	case c.events <- e:
+	default:
+		c.emitErr(types.WithSource(types.KindWebSocket, ErrQueueOverflow))
+	}
+}
+
+func (c *Client) emitErr(err error) {
+	select {
+	case c.errs <- err:
+	default:
+		// Errors channel is also full; drop silently rather than block the reader.
+	}
+}
+
+// Close cancels all background loops belonging to this client. Callers
+// typically manage lifetime via the ctx passed to Subscribe*; Close is
+// provided for explicit teardown of open sockets.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var err error
+	if c.marketConn != nil {
+		err = c.marketConn.close()
+	}
+	if c.userConn != nil {
+		if uerr := c.userConn.close(); uerr != nil && err == nil {
+			err = uerr
+		}
+	}
+	return err
+}
@@ -0,0 +1,153 @@
+package wsstream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func echoSubscribeServer(t *testing.T, send func(*websocket.Conn)) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Drain the subscribe frame, then push fixtures.
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+		send(conn)
+
+		// Keep the socket open briefly so the client can read before the test ends.
+		time.Sleep(200 * time.Millisecond)
+	}))
+}
+
+func wsURL(srv *httptest.Server) string {
+	return "ws" + srv.URL[len("http"):]
+}
+
+func TestClient_MarketChannel_DispatchesBookUpdate(t *testing.T) {
+	srv := echoSubscribeServer(t, func(c *websocket.Conn) {
+		_ = c.WriteJSON(map[string]any{
+			"event_type": "book",
+			"asset_id":   "123",
+			"market":     "m1",
+			"bids":       []PriceLevel{{Price: "0.5", Size: "10"}},
+		})
+	})
+	defer srv.Close()
+
+	c := NewClient(wsURL(srv), "", WithQueueSize(4))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := c.SubscribeMarket(ctx, "123"); err != nil {
+		t.Fatalf("SubscribeMarket: %v", err)
+	}
+
+	select {
+	case ev := <-c.Events():
+		if ev.Kind != EventBookUpdate || ev.BookUpdate == nil || ev.BookUpdate.AssetID != "123" {
+			t.Fatalf("unexpected event: %+v", ev)
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for book update")
+	}
+}
+
+func TestClient_SequenceGapSurfacesSyncError(t *testing.T) {
+	srv := echoSubscribeServer(t, func(c *websocket.Conn) {
+		_ = c.WriteJSON(map[string]any{"event_type": "price_change", "asset_id": "123", "seq": 1})
+		_ = c.WriteJSON(map[string]any{"event_type": "price_change", "asset_id": "123", "seq": 5})
+	})
+	defer srv.Close()
+
+	c := NewClient(wsURL(srv), "", WithQueueSize(4))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := c.SubscribeMarket(ctx, "123"); err != nil {
+		t.Fatalf("SubscribeMarket: %v", err)
+	}
+
+	// Drain both price_change events.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-c.Events():
+		case <-time.After(1 * time.Second):
+			t.Fatal("timed out waiting for price_change event")
+		}
+	}
+
+	select {
+	case err := <-c.Errors():
+		if err == nil {
+			t.Fatal("expected a synchronization error")
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("timed out waiting for synchronization error")
+	}
+}
+
+func TestClient_ReconnectResetsSequenceBaseline(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	var conns int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+
+		if atomic.AddInt32(&conns, 1) == 1 {
+			// First connection: send a high sequence number, then drop the
+			// socket immediately to force a reconnect.
+			_ = conn.WriteJSON(map[string]any{"event_type": "price_change", "asset_id": "123", "seq": 9})
+			return
+		}
+
+		// Reconnect: the server restarts sequencing from 1, unrelated to
+		// what the first connection sent.
+		_ = conn.WriteJSON(map[string]any{"event_type": "price_change", "asset_id": "123", "seq": 1})
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	c := NewClient(wsURL(srv), "", WithQueueSize(4),
+		WithBackoff(BackoffConfig{Base: time.Millisecond, Max: 5 * time.Millisecond}))
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := c.SubscribeMarket(ctx, "123"); err != nil {
+		t.Fatalf("SubscribeMarket: %v", err)
+	}
+
+	// Drain the price_change event from both the first connection and the
+	// post-reconnect one.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-c.Events():
+		case <-time.After(1 * time.Second):
+			t.Fatal("timed out waiting for price_change event")
+		}
+	}
+
+	select {
+	case err := <-c.Errors():
+		t.Fatalf("expected no synchronization error across a reconnect, got: %v", err)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
@@ -0,0 +1,57 @@
+package wsstream
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// connection is the low-level layer: dialing, framing, ping/pong keepalive and
+// read-deadline management. It knows nothing about subscriptions or message
+// dispatch - that lives in the flow layer (client.go).
+type connection struct {
+	ws *websocket.Conn
+}
+
+const (
+	pongWait         = 30 * time.Second
+	pingEvery        = 15 * time.Second
+	writeWait        = 10 * time.Second
+	handshakeTimeout = 10 * time.Second
+)
+
+func dial(ctx context.Context, url string, header http.Header) (*connection, *http.Response, error) {
+	dialer := websocket.Dialer{HandshakeTimeout: handshakeTimeout}
+	ws, resp, err := dialer.DialContext(ctx, url, header)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	ws.SetReadDeadline(time.Now().Add(pongWait))
+	ws.SetPongHandler(func(string) error {
+		return ws.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	return &connection{ws: ws}, resp, nil
+}
+
+func (c *connection) readMessage() ([]byte, error) {
+	_, b, err := c.ws.ReadMessage()
+	return b, err
+}
+
+func (c *connection) writeJSON(v any) error {
+	c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+	return c.ws.WriteJSON(v)
+}
+
+func (c *connection) ping() error {
+	c.ws.SetWriteDeadline(time.Now().Add(writeWait))
+	return c.ws.WriteMessage(websocket.PingMessage, nil)
+}
+
+func (c *connection) close() error {
+	return c.ws.Close()
+}
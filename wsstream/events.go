@@ -0,0 +1,115 @@
+// Package wsstream implements a client for Polymarket's CLOB WebSocket feeds
+// (the public "market" channel and the authenticated "user" channel).
+package wsstream
+
+// EventKind identifies the concrete type carried by an Event.
+type EventKind string
+
+const (
+	EventBookUpdate     EventKind = "book"
+	EventPriceChange    EventKind = "price_change"
+	EventTickSizeChange EventKind = "tick_size_change"
+	EventLastTradePrice EventKind = "last_trade_price"
+	EventOrderPlaced    EventKind = "order_placed"
+	EventOrderMatched   EventKind = "order_matched"
+	EventOrderCancelled EventKind = "order_cancelled"
+)
+
+// PriceLevel is a single price/size pair in an order book.
+type PriceLevel struct {
+	Price string `json:"price"`
+	Size  string `json:"size"`
+}
+
+// BookUpdate is a full order book snapshot for a single asset.
+type BookUpdate struct {
+	AssetID   string       `json:"asset_id"`
+	Market    string       `json:"market"`
+	Bids      []PriceLevel `json:"bids"`
+	Asks      []PriceLevel `json:"asks"`
+	Hash      string       `json:"hash"`
+	Timestamp string       `json:"timestamp"`
+}
+
+// PriceChange is an incremental book delta for a single price level.
+type PriceChange struct {
+	AssetID   string `json:"asset_id"`
+	Market    string `json:"market"`
+	Price     string `json:"price"`
+	Side      string `json:"side"`
+	Size      string `json:"size"`
+	BestBid   string `json:"best_bid"`
+	BestAsk   string `json:"best_ask"`
+	Hash      string `json:"hash"`
+	Timestamp string `json:"timestamp"`
+	Seq       uint64 `json:"seq,omitempty"`
+}
+
+// TickSizeChange notifies that a market's minimum tick size changed.
+type TickSizeChange struct {
+	AssetID     string `json:"asset_id"`
+	Market      string `json:"market"`
+	OldTickSize string `json:"old_tick_size"`
+	NewTickSize string `json:"new_tick_size"`
+	Timestamp   string `json:"timestamp"`
+}
+
+// LastTradePrice is emitted whenever a trade prints for an asset.
+type LastTradePrice struct {
+	AssetID   string `json:"asset_id"`
+	Market    string `json:"market"`
+	Price     string `json:"price"`
+	Side      string `json:"side"`
+	Size      string `json:"size"`
+	Timestamp string `json:"timestamp"`
+}
+
+// OrderPlaced is emitted on the user channel when one of the caller's orders is accepted.
+type OrderPlaced struct {
+	OrderID   string `json:"order_id"`
+	AssetID   string `json:"asset_id"`
+	Market    string `json:"market"`
+	Side      string `json:"side"`
+	Price     string `json:"price"`
+	Size      string `json:"size"`
+	Timestamp string `json:"timestamp"`
+}
+
+// OrderMatched is emitted on the user channel when one of the caller's orders fills (fully or partially).
+type OrderMatched struct {
+	OrderID        string `json:"order_id"`
+	MatchedOrderID string `json:"matched_order_id"`
+	AssetID        string `json:"asset_id"`
+	Market         string `json:"market"`
+	Price          string `json:"price"`
+	Size           string `json:"size"`
+	Timestamp      string `json:"timestamp"`
+}
+
+// OrderCancelled is emitted on the user channel when one of the caller's orders is cancelled.
+type OrderCancelled struct {
+	OrderID   string `json:"order_id"`
+	AssetID   string `json:"asset_id"`
+	Market    string `json:"market"`
+	Reason    string `json:"reason"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Event is a single typed message delivered on Client.Events().
+// Exactly one of the payload fields is non-nil, matching Kind.
+type Event struct {
+	Kind EventKind
+
+	BookUpdate     *BookUpdate
+	PriceChange    *PriceChange
+	TickSizeChange *TickSizeChange
+	LastTradePrice *LastTradePrice
+	OrderPlaced    *OrderPlaced
+	OrderMatched   *OrderMatched
+	OrderCancelled *OrderCancelled
+}
+
+// wireMessage is the envelope Polymarket wraps every channel message in.
+type wireMessage struct {
+	EventType string `json:"event_type"`
+}